@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"aur-update-checker/internal/checkers/common"
 	"aur-update-checker/internal/interfaces/checkers"
 )
 
@@ -88,6 +89,16 @@ func (c *GitLabCheckerExample) CheckWithVersionRef(ctx context.Context, url, ver
 	return normalizedVersion, nil
 }
 
+// CheckWithResult 返回包含版本号的检查结果
+// 实际实现中可以填充从GitLab API获取的发布时间和发布页面地址
+func (c *GitLabCheckerExample) CheckWithResult(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (*common.UpstreamCheckResult, error) {
+	version, err := c.CheckWithVersionRef(ctx, url, versionExtractKey, versionRef, checkTestVersion)
+	if err != nil {
+		return nil, err
+	}
+	return common.NewVersionResult(version, url), nil
+}
+
 // PluginInfo 返回插件信息
 func (c *GitLabCheckerExample) PluginInfo() checkers.PluginInfo {
 	return checkers.PluginInfo{
@@ -33,7 +33,8 @@ export const addPackage = (data) => {
       upstreamUrl: data.upstreamUrl,
       versionExtractKey: data.versionExtractKey || '',
       upstreamChecker: checker,
-      checkTestVersion: data.checkTestVersion || 0
+      checkTestVersion: data.checkTestVersion || 0,
+      checkerOptions: data.checkerOptions || ''
     }).then(response => response.data);
   } catch (error) {
     console.error('添加软件包失败:', error);
@@ -47,7 +48,8 @@ export const updatePackage = (id, data) => {
     upstreamUrl: data.upstreamUrl,
     versionExtractKey: data.versionExtractKey,
     upstreamChecker: data.upstreamChecker || '',
-    checkTestVersion: data.checkTestVersion || 0
+    checkTestVersion: data.checkTestVersion || 0,
+    checkerOptions: data.checkerOptions || ''
   }).then(response => response.data);
 }
 
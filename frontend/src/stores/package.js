@@ -13,10 +13,8 @@ export const usePackageStore = defineStore('package', {
   getters: {
     // 获取需要更新的软件包数量
     outdatedPackagesCount: (state) => {
-      return state.packages.filter(pkg => {
-        if (!pkg.aurVersion || !pkg.upstreamVersion) return false
-        return pkg.aurVersion !== pkg.upstreamVersion
-      }).length
+      // 是否需要更新由后端比较版本后给出，VCS版本按提交数比较
+      return state.packages.filter(pkg => pkg.needUpdate).length
     },
 
     // 获取检查失败的软件包数量
@@ -42,6 +42,7 @@ export function usePackageActions() {
       upstreamChecker: record.upstreamChecker,
       versionExtractKey: record.versionExtractKey || '',
       checkTestVersion: record.checkTestVersion || 0,
+      checkerOptions: record.checkerOptions || '',
       aurVersion: record.aurVersion,
       upstreamVersion: record.upstreamVersion,
       aurUpdateState: record.aurUpdateState,
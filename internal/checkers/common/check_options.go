@@ -0,0 +1,64 @@
+package common
+
+import (
+	"context"
+	"net/url"
+	"strings"
+)
+
+// CheckOptions 软件包级别的检查器选项
+// 在软件包中以URL查询字符串格式保存，如 tag_strategy=date&branch=main
+type CheckOptions map[string]string
+
+// checkOptionsKey 检查器选项在context中的键
+type checkOptionsKey struct{}
+
+// ParseCheckOptions 解析查询字符串格式的检查器选项，同一选项出现多次时使用第一个值
+func ParseCheckOptions(raw string) CheckOptions {
+	options := make(CheckOptions)
+
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return options
+	}
+
+	// 允许使用换行分隔多个选项，便于在界面中编辑
+	raw = strings.NewReplacer("\r\n", "&", "\n", "&").Replace(raw)
+
+	values, err := url.ParseQuery(raw)
+	if err != nil && len(values) == 0 {
+		return options
+	}
+
+	for key, vals := range values {
+		key = strings.TrimSpace(key)
+		if key == "" || len(vals) == 0 {
+			continue
+		}
+		options[key] = strings.TrimSpace(vals[0])
+	}
+	return options
+}
+
+// Get 获取选项值，选项不存在或为空时返回默认值
+func (o CheckOptions) Get(key, defaultValue string) string {
+	if value, ok := o[key]; ok && value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// WithCheckOptions 返回携带检查器选项的context
+func WithCheckOptions(ctx context.Context, options CheckOptions) context.Context {
+	return context.WithValue(ctx, checkOptionsKey{}, options)
+}
+
+// CheckOptionsFromContext 从context中获取检查器选项，未设置时返回空选项
+func CheckOptionsFromContext(ctx context.Context) CheckOptions {
+	if ctx != nil {
+		if options, ok := ctx.Value(checkOptionsKey{}).(CheckOptions); ok {
+			return options
+		}
+	}
+	return CheckOptions{}
+}
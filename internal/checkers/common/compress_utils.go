@@ -0,0 +1,97 @@
+package common
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+const (
+	// CompressionNone 未压缩
+	CompressionNone = ""
+	// CompressionGzip gzip压缩，扩展名 .gz
+	CompressionGzip = "gzip"
+	// CompressionZstd zstd压缩，扩展名 .zst
+	CompressionZstd = "zstd"
+	// CompressionXz xz压缩，扩展名 .xz
+	CompressionXz = "xz"
+	// CompressionBzip2 bzip2压缩，扩展名 .bz2
+	CompressionBzip2 = "bzip2"
+)
+
+// compressionExtensions 扩展名对应的压缩格式
+var compressionExtensions = map[string]string{
+	".gz":   CompressionGzip,
+	".zst":  CompressionZstd,
+	".zstd": CompressionZstd,
+	".xz":   CompressionXz,
+	".bz2":  CompressionBzip2,
+}
+
+// compressionMagics 各压缩格式的文件头
+var compressionMagics = []struct {
+	compression string
+	magic       []byte
+}{
+	{CompressionGzip, []byte{0x1f, 0x8b}},
+	{CompressionZstd, []byte{0x28, 0xb5, 0x2f, 0xfd}},
+	{CompressionXz, []byte{0xfd, '7', 'z', 'X', 'Z', 0x00}},
+	{CompressionBzip2, []byte("BZh")},
+}
+
+// CompressionFromName 根据文件名或URL的扩展名判断压缩格式，扩展名未知时返回 CompressionNone
+func CompressionFromName(name string) string {
+	if i := strings.IndexAny(name, "?#"); i >= 0 {
+		name = name[:i]
+	}
+	return compressionExtensions[strings.ToLower(path.Ext(name))]
+}
+
+// NewDecompressReader 按扩展名选择解压方式，扩展名不是已知的压缩格式时根据文件头判断，都不是时按未压缩读取
+// 返回的 ReadCloser 只释放解压器占用的资源，不关闭 reader
+func NewDecompressReader(name string, reader io.Reader) (io.ReadCloser, error) {
+	compression := CompressionFromName(name)
+	if compression == CompressionNone {
+		buffered := bufio.NewReader(reader)
+		header, _ := buffered.Peek(6)
+		for _, candidate := range compressionMagics {
+			if bytes.HasPrefix(header, candidate.magic) {
+				compression = candidate.compression
+				break
+			}
+		}
+		reader = buffered
+	}
+
+	switch compression {
+	case CompressionGzip:
+		gzReader, err := gzip.NewReader(reader)
+		if err != nil {
+			return nil, fmt.Errorf("解压gzip失败: %v", err)
+		}
+		return gzReader, nil
+	case CompressionZstd:
+		zstdReader, err := zstd.NewReader(reader)
+		if err != nil {
+			return nil, fmt.Errorf("解压zstd失败: %v", err)
+		}
+		return zstdReader.IOReadCloser(), nil
+	case CompressionXz:
+		xzReader, err := xz.NewReader(reader)
+		if err != nil {
+			return nil, fmt.Errorf("解压xz失败: %v", err)
+		}
+		return io.NopCloser(xzReader), nil
+	case CompressionBzip2:
+		return io.NopCloser(bzip2.NewReader(reader)), nil
+	}
+	return io.NopCloser(reader), nil
+}
@@ -0,0 +1,98 @@
+package common
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+const compressTestContent = "<metadata/>"
+
+// compressTestBzip2 compressTestContent 的bzip2压缩结果，标准库没有bzip2压缩
+var compressTestBzip2 = []byte{
+	0x42, 0x5a, 0x68, 0x39, 0x31, 0x41, 0x59, 0x26, 0x53, 0x59, 0xd0, 0x43,
+	0x7f, 0x9c, 0x00, 0x00, 0x00, 0x99, 0x80, 0x00, 0x00, 0x80, 0x05, 0x26,
+	0x02, 0x04, 0x00, 0x20, 0x00, 0x22, 0x0c, 0x9a, 0x7a, 0x84, 0x30, 0x21,
+	0xb4, 0x54, 0xd3, 0x91, 0xe2, 0xee, 0x48, 0xa7, 0x0a, 0x12, 0x1a, 0x08,
+	0x6f, 0xf3, 0x80,
+}
+
+func compressTestData(t *testing.T, compression string) []byte {
+	t.Helper()
+	var buffer bytes.Buffer
+	var writer io.WriteCloser
+	var err error
+	switch compression {
+	case CompressionGzip:
+		writer = gzip.NewWriter(&buffer)
+	case CompressionZstd:
+		writer, err = zstd.NewWriter(&buffer)
+	case CompressionXz:
+		writer, err = xz.NewWriter(&buffer)
+	case CompressionBzip2:
+		return compressTestBzip2
+	default:
+		return []byte(compressTestContent)
+	}
+	if err != nil {
+		t.Fatalf("创建%s压缩器失败: %v", compression, err)
+	}
+	if _, err := writer.Write([]byte(compressTestContent)); err != nil {
+		t.Fatalf("%s压缩失败: %v", compression, err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("%s压缩失败: %v", compression, err)
+	}
+	return buffer.Bytes()
+}
+
+func TestNewDecompressReader(t *testing.T) {
+	tests := []struct {
+		name        string
+		file        string
+		compression string
+	}{
+		{"未压缩", "primary.xml", CompressionNone},
+		{"gzip扩展名", "primary.xml.gz", CompressionGzip},
+		{"zstd扩展名", "primary.xml.zst", CompressionZstd},
+		{"xz扩展名", "primary.xml.xz", CompressionXz},
+		{"bzip2扩展名", "primary.xml.bz2", CompressionBzip2},
+		{"大写扩展名", "PRIMARY.XML.GZ", CompressionGzip},
+		{"URL中的查询参数", "https://example.com/repodata/primary.xml.zst?token=1", CompressionZstd},
+		{"根据文件头判断gzip", "primary", CompressionGzip},
+		{"根据文件头判断zstd", "primary.xml", CompressionZstd},
+		{"根据文件头判断xz", "primary.xml", CompressionXz},
+		{"根据文件头判断bzip2", "download", CompressionBzip2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := NewDecompressReader(tt.file, bytes.NewReader(compressTestData(t, tt.compression)))
+			if err != nil {
+				t.Fatalf("NewDecompressReader(%q) 返回错误: %v", tt.file, err)
+			}
+			defer reader.Close()
+			content, err := io.ReadAll(reader)
+			if err != nil {
+				t.Fatalf("读取 %q 失败: %v", tt.file, err)
+			}
+			if string(content) != compressTestContent {
+				t.Errorf("NewDecompressReader(%q) 读取到 %q，期望 %q", tt.file, content, compressTestContent)
+			}
+		})
+	}
+}
+
+func TestNewDecompressReaderErrors(t *testing.T) {
+	// 扩展名与内容不符
+	if _, err := NewDecompressReader("primary.xml.gz", bytes.NewReader([]byte(compressTestContent))); err == nil {
+		t.Errorf("未压缩的内容按gzip解压时期望返回错误")
+	}
+	if _, err := NewDecompressReader("primary.xml.xz", bytes.NewReader(compressTestData(t, CompressionGzip))); err == nil {
+		t.Errorf("gzip压缩的内容按xz解压时期望返回错误")
+	}
+}
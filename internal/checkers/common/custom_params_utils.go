@@ -0,0 +1,106 @@
+package common
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GetStringParam 从检查器自定义参数中读取字符串参数
+// 参数不存在或类型不匹配时返回默认值
+func GetStringParam(params map[string]interface{}, key, defaultValue string) string {
+	if params == nil {
+		return defaultValue
+	}
+
+	value, ok := params[key]
+	if !ok || value == nil {
+		return defaultValue
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return fmt.Sprintf("%v", value)
+	}
+	return str
+}
+
+// GetStringSliceParam 从检查器自定义参数中读取字符串列表参数
+// 同时支持JSON数组和逗号分隔的字符串两种写法
+func GetStringSliceParam(params map[string]interface{}, key string) []string {
+	if params == nil {
+		return nil
+	}
+
+	var result []string
+	switch v := params[key].(type) {
+	case []string:
+		for _, item := range v {
+			if item = strings.TrimSpace(item); item != "" {
+				result = append(result, item)
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if str, ok := item.(string); ok {
+				if str = strings.TrimSpace(str); str != "" {
+					result = append(result, str)
+				}
+			}
+		}
+	case string:
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				result = append(result, item)
+			}
+		}
+	}
+	return result
+}
+
+// GetIntParam 从检查器自定义参数中读取整数参数
+// JSON中的数字会被解析为float64，也兼容字符串形式的数字
+func GetIntParam(params map[string]interface{}, key string, defaultValue int) int {
+	if params == nil {
+		return defaultValue
+	}
+
+	switch v := params[key].(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	case string:
+		if n, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
+			return n
+		}
+	}
+	return defaultValue
+}
+
+// GetStringMapParam 从检查器自定义参数中读取字符串映射参数，如 {"@scope": "https://npm.example.com"}
+// 值不是字符串的项会被忽略
+func GetStringMapParam(params map[string]interface{}, key string) map[string]string {
+	if params == nil {
+		return nil
+	}
+
+	result := make(map[string]string)
+	switch v := params[key].(type) {
+	case map[string]string:
+		for k, item := range v {
+			if item = strings.TrimSpace(item); item != "" {
+				result[strings.TrimSpace(k)] = item
+			}
+		}
+	case map[string]interface{}:
+		for k, item := range v {
+			if str, ok := item.(string); ok {
+				if str = strings.TrimSpace(str); str != "" {
+					result[strings.TrimSpace(k)] = str
+				}
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,972 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	versionProcessor "aur-update-checker/internal/checkers/version"
+)
+
+// DocumentPath 编译后的JSONPath/jq风格的路径表达式，用于从JSON、YAML、TOML文档中取值
+// 表达式由 | 分隔的多个阶段组成，每个阶段是路径、select(条件)、数组函数（first、last）或归约函数（max_version）：
+//
+//	version                                      兼容原来的点分隔路径
+//	$.releases[0].version                        数组下标，可以是负数
+//	releases[*].version、.releases[].version      通配符，取全部元素
+//	$..version                                   递归查找
+//	releases[?(@.channel=='stable')].version     JSONPath过滤器
+//	releases | last | .version                   取数组的最后一个元素
+//	.releases[] | select(.channel == "stable") | .version | max_version
+type DocumentPath struct {
+	expr   string
+	stages []documentStage
+	// legacyKeys 不以 $ 或 . 开头的表达式按原来的方式拆分的键，求值时先按键逐级查找对象，
+	// 使 @scope/pkg.version、a|b 等包含表达式语法字符的键保持原来的含义
+	legacyKeys []string
+}
+
+// documentStage 表达式中的一个阶段
+type documentStage struct {
+	// segments 路径阶段的各段
+	segments []documentSegment
+	// condition select() 阶段的条件
+	condition documentCondition
+	// function 数组函数阶段的函数名
+	function string
+	// reducer 归约阶段的函数名
+	reducer string
+}
+
+// documentSegmentKind 路径段的类型
+type documentSegmentKind int
+
+const (
+	documentKeys documentSegmentKind = iota
+	documentIndexes
+	documentWildcard
+	documentSlice
+	documentFilter
+	// documentRecursive 递归查找，取当前值及全部后代值，后面跟随要匹配的段
+	documentRecursive
+)
+
+// documentSegment 路径中的一段
+type documentSegment struct {
+	kind    documentSegmentKind
+	keys    []string
+	indexes []int
+	// sliceStart、sliceEnd 切片的范围，nil表示省略
+	sliceStart, sliceEnd *int
+	filter               documentCondition
+}
+
+// documentCondition 过滤器或 select() 中的条件
+type documentCondition interface {
+	matches(value interface{}) bool
+}
+
+type (
+	// documentAndCondition && 或 and
+	documentAndCondition struct{ left, right documentCondition }
+	// documentOrCondition || 或 or
+	documentOrCondition struct{ left, right documentCondition }
+	// documentNotCondition ! 或 not
+	documentNotCondition struct{ operand documentCondition }
+	// documentCompareCondition 比较运算，op为空时检查左侧的值是否存在且不为false、null
+	documentCompareCondition struct {
+		op          string
+		left, right documentOperand
+		regex       *regexp.Regexp
+	}
+)
+
+// documentOperand 条件中的操作数：相对于当前元素的路径（@.a 或 .a）或字面量
+type documentOperand struct {
+	path    []documentSegment
+	isPath  bool
+	literal interface{}
+}
+
+// documentReducers 支持的归约函数，对全部结果求值
+var documentReducers = map[string]bool{"max_version": true}
+
+// documentFunctions 支持的数组函数，与jq相同，对每个数组结果取第一个或最后一个元素
+var documentFunctions = map[string]bool{"first": true, "last": true}
+
+// CompileDocumentPath 编译路径表达式
+// 不以 $ 或 . 开头的表达式也是原来的点分隔路径，无法按表达式语法解析时只按原来的方式查找，不返回错误
+func CompileDocumentPath(expr string) (*DocumentPath, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return nil, fmt.Errorf("路径表达式为空")
+	}
+
+	var legacyKeys []string
+	if !strings.HasPrefix(expr, "$") && !strings.HasPrefix(expr, ".") {
+		legacyKeys = strings.Split(expr, ".")
+	}
+	path, err := compileDocumentStages(expr)
+	if err != nil {
+		if legacyKeys == nil {
+			return nil, err
+		}
+		return &DocumentPath{expr: expr, legacyKeys: legacyKeys}, nil
+	}
+	path.legacyKeys = legacyKeys
+	return path, nil
+}
+
+// compileDocumentStages 按表达式语法编译各个阶段
+func compileDocumentStages(expr string) (*DocumentPath, error) {
+	path := &DocumentPath{expr: expr}
+	// 第一个阶段总是路径，select()、数组函数和归约函数只出现在 | 之后，不会与同名的键冲突
+	for i, part := range splitTopLevel(expr, '|') {
+		part = strings.TrimSpace(part)
+		switch {
+		case part == "":
+			return nil, fmt.Errorf("路径表达式 %s 中有空的阶段", expr)
+		case i > 0 && documentReducers[part]:
+			path.stages = append(path.stages, documentStage{reducer: part})
+		case i > 0 && documentFunctions[part]:
+			path.stages = append(path.stages, documentStage{function: part})
+		case i > 0 && strings.HasPrefix(part, "select(") && strings.HasSuffix(part, ")"):
+			p := &documentParser{input: part[len("select(") : len(part)-1], current: '.'}
+			condition, err := p.parseCondition()
+			if err != nil {
+				return nil, fmt.Errorf("解析 %s 失败: %v", part, err)
+			}
+			if p.skipSpace(); p.pos < len(p.input) {
+				return nil, fmt.Errorf("解析 %s 失败: 位置%d处有多余的内容", part, p.pos)
+			}
+			path.stages = append(path.stages, documentStage{condition: condition})
+		default:
+			p := &documentParser{input: part, current: '@'}
+			segments, err := p.parsePath(true)
+			if err != nil {
+				return nil, fmt.Errorf("解析 %s 失败: %v", part, err)
+			}
+			if p.skipSpace(); p.pos < len(p.input) {
+				return nil, fmt.Errorf("解析 %s 失败: 位置%d处有无法解析的字符 %q", part, p.pos, p.input[p.pos])
+			}
+			path.stages = append(path.stages, documentStage{segments: segments})
+		}
+	}
+	return path, nil
+}
+
+// Evaluate 对文档求值，返回全部结果；max_version 只在includePrerelease为true时考虑测试版本
+func (p *DocumentPath) Evaluate(document interface{}, includePrerelease bool) ([]interface{}, error) {
+	document = NormalizeDocument(document)
+	if p.legacyKeys != nil {
+		if value, ok := lookupDocumentKeys(document, p.legacyKeys); ok {
+			return []interface{}{value}, nil
+		}
+		if p.stages == nil {
+			return nil, nil
+		}
+	}
+
+	values := []interface{}{document}
+	for _, stage := range p.stages {
+		switch {
+		case stage.function != "":
+			values = applyDocumentFunction(stage.function, values)
+		case stage.reducer != "":
+			if len(values) == 0 {
+				return nil, fmt.Errorf("%s 的输入为空", stage.reducer)
+			}
+			reduced, err := reduceDocumentValues(stage.reducer, values, includePrerelease)
+			if err != nil {
+				return nil, err
+			}
+			values = []interface{}{reduced}
+		case stage.condition != nil:
+			var selected []interface{}
+			for _, value := range values {
+				if stage.condition.matches(value) {
+					selected = append(selected, value)
+				}
+			}
+			values = selected
+		default:
+			values = applyDocumentSegments(values, stage.segments)
+		}
+	}
+	return values, nil
+}
+
+// String 返回原始表达式
+func (p *DocumentPath) String() string {
+	return p.expr
+}
+
+// DocumentValueString 把文档中的值转换为字符串，对象和数组转换为JSON
+func DocumentValueString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case map[string]interface{}, []interface{}:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(data)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// NormalizeDocument 把YAML、TOML解码的值统一为JSON的类型：对象为 map[string]interface{}，数组为 []interface{}，数字为 float64，时间为RFC 3339字符串
+func NormalizeDocument(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = NormalizeDocument(item)
+		}
+		return v
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			converted[fmt.Sprint(key)] = NormalizeDocument(item)
+		}
+		return converted
+	case []interface{}:
+		for i, item := range v {
+			v[i] = NormalizeDocument(item)
+		}
+		return v
+	case []map[string]interface{}:
+		// TOML的表数组
+		converted := make([]interface{}, len(v))
+		for i, item := range v {
+			converted[i] = NormalizeDocument(item)
+		}
+		return converted
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	}
+	return value
+}
+
+// lookupDocumentKeys 按原来的方式逐级查找对象中的键，键中的字符没有特殊含义
+func lookupDocumentKeys(document interface{}, keys []string) (interface{}, bool) {
+	current := document
+	for _, key := range keys {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = object[key]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// applyDocumentFunction 对每个数组结果取第一个或最后一个元素，不是数组或数组为空时不产生结果
+func applyDocumentFunction(function string, values []interface{}) []interface{} {
+	var results []interface{}
+	for _, value := range values {
+		array, ok := value.([]interface{})
+		if !ok || len(array) == 0 {
+			continue
+		}
+		if function == "first" {
+			results = append(results, array[0])
+		} else {
+			results = append(results, array[len(array)-1])
+		}
+	}
+	return results
+}
+
+// applyDocumentSegments 对每个值依次应用路径段
+func applyDocumentSegments(values []interface{}, segments []documentSegment) []interface{} {
+	for i := 0; i < len(segments); i++ {
+		segment := segments[i]
+		var next []interface{}
+		if segment.kind == documentRecursive {
+			// 递归查找与后面的一段组合使用，如 ..version、..[0]
+			var all []interface{}
+			for _, value := range values {
+				all = appendDescendants(all, value)
+			}
+			values = all
+			continue
+		}
+		for _, value := range values {
+			next = append(next, segment.apply(value)...)
+		}
+		values = next
+	}
+	return values
+}
+
+// appendDescendants 按文档顺序添加值及其全部后代值，对象的键按字母顺序
+func appendDescendants(values []interface{}, value interface{}) []interface{} {
+	values = append(values, value)
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, key := range sortedKeys(v) {
+			values = appendDescendants(values, v[key])
+		}
+	case []interface{}:
+		for _, item := range v {
+			values = appendDescendants(values, item)
+		}
+	}
+	return values
+}
+
+// apply 对单个值应用路径段，不存在的键和越界的下标不产生结果
+func (s documentSegment) apply(value interface{}) []interface{} {
+	var results []interface{}
+	switch s.kind {
+	case documentKeys:
+		for _, key := range s.keys {
+			switch v := value.(type) {
+			case map[string]interface{}:
+				if item, ok := v[key]; ok {
+					results = append(results, item)
+				}
+			case []interface{}:
+				// 兼容原来的点分隔路径中的数组下标，如 assets.0.name
+				if index, err := strconv.Atoi(key); err == nil {
+					if item, ok := indexDocumentArray(v, index); ok {
+						results = append(results, item)
+					}
+				}
+			}
+		}
+	case documentIndexes:
+		if array, ok := value.([]interface{}); ok {
+			for _, index := range s.indexes {
+				if item, ok := indexDocumentArray(array, index); ok {
+					results = append(results, item)
+				}
+			}
+		}
+	case documentWildcard:
+		switch v := value.(type) {
+		case map[string]interface{}:
+			for _, key := range sortedKeys(v) {
+				results = append(results, v[key])
+			}
+		case []interface{}:
+			results = append(results, v...)
+		}
+	case documentSlice:
+		if array, ok := value.([]interface{}); ok {
+			start, end := 0, len(array)
+			if s.sliceStart != nil {
+				start = clampDocumentIndex(*s.sliceStart, len(array))
+			}
+			if s.sliceEnd != nil {
+				end = clampDocumentIndex(*s.sliceEnd, len(array))
+			}
+			if start < end {
+				results = append(results, array[start:end]...)
+			}
+		}
+	case documentFilter:
+		switch v := value.(type) {
+		case []interface{}:
+			for _, item := range v {
+				if s.filter.matches(item) {
+					results = append(results, item)
+				}
+			}
+		case map[string]interface{}:
+			for _, key := range sortedKeys(v) {
+				if s.filter.matches(v[key]) {
+					results = append(results, v[key])
+				}
+			}
+		}
+	}
+	return results
+}
+
+// indexDocumentArray 取数组元素，负数下标从末尾开始
+func indexDocumentArray(array []interface{}, index int) (interface{}, bool) {
+	if index < 0 {
+		index += len(array)
+	}
+	if index < 0 || index >= len(array) {
+		return nil, false
+	}
+	return array[index], true
+}
+
+// clampDocumentIndex 把切片下标限制在数组范围内，负数从末尾开始
+func clampDocumentIndex(index, length int) int {
+	if index < 0 {
+		index += length
+	}
+	return max(0, min(index, length))
+}
+
+// sortedKeys 返回按字母顺序排列的键，使通配符的结果稳定
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// reduceDocumentValues 执行归约函数，结果中的数组展开为元素，如 releases[*].version 和 versions 都可以使用 max_version
+func reduceDocumentValues(reducer string, values []interface{}, includePrerelease bool) (interface{}, error) {
+	var candidates []interface{}
+	for _, value := range values {
+		if array, ok := value.([]interface{}); ok {
+			candidates = append(candidates, array...)
+		} else {
+			candidates = append(candidates, value)
+		}
+	}
+
+	comparator := versionProcessor.NewVersionComparator()
+	var latest interface{}
+	latestVersion := ""
+	for _, value := range candidates {
+		version := DocumentValueString(value)
+		switch value.(type) {
+		case map[string]interface{}, []interface{}, nil:
+			continue
+		}
+		if version == "" || (!includePrerelease && !comparator.IsStableVersion(version)) {
+			continue
+		}
+		if latest == nil || comparator.CompareVersions(version, latestVersion) > 0 {
+			latest, latestVersion = value, version
+		}
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("%s 在%d个值中没有找到符合条件的版本", reducer, len(candidates))
+	}
+	return latest, nil
+}
+
+func (c *documentAndCondition) matches(value interface{}) bool {
+	return c.left.matches(value) && c.right.matches(value)
+}
+
+func (c *documentOrCondition) matches(value interface{}) bool {
+	return c.left.matches(value) || c.right.matches(value)
+}
+
+func (c *documentNotCondition) matches(value interface{}) bool {
+	return !c.operand.matches(value)
+}
+
+func (c *documentCompareCondition) matches(value interface{}) bool {
+	left, leftOK := c.left.resolve(value)
+	if c.op == "" {
+		return leftOK && left != nil && left != false
+	}
+	right, rightOK := c.right.resolve(value)
+	if !leftOK || !rightOK {
+		// 不存在的字段只满足 !=
+		return c.op == "!=" && leftOK != rightOK
+	}
+
+	switch c.op {
+	case "=~":
+		s, ok := left.(string)
+		return ok && c.regex.MatchString(s)
+	case "==":
+		return compareDocumentValues(left, right) == 0
+	case "!=":
+		return compareDocumentValues(left, right) != 0
+	}
+
+	// 大小比较只在两侧都是数字或都是字符串时成立，字符串按版本号比较
+	_, leftNumber := left.(float64)
+	_, rightNumber := right.(float64)
+	_, leftString := left.(string)
+	_, rightString := right.(string)
+	if !(leftNumber && rightNumber) && !(leftString && rightString) {
+		return false
+	}
+	result := compareDocumentValues(left, right)
+	switch c.op {
+	case "<":
+		return result < 0
+	case "<=":
+		return result <= 0
+	case ">":
+		return result > 0
+	case ">=":
+		return result >= 0
+	}
+	return false
+}
+
+// resolve 返回操作数的值，路径没有结果时返回false
+func (o documentOperand) resolve(value interface{}) (interface{}, bool) {
+	if !o.isPath {
+		return o.literal, true
+	}
+	results := applyDocumentSegments([]interface{}{value}, o.path)
+	if len(results) == 0 {
+		return nil, false
+	}
+	return results[0], true
+}
+
+// compareDocumentValues 比较两个值：数字按大小，字符串按版本号（不是版本号时按字典序），其他类型只比较是否相等
+func compareDocumentValues(left, right interface{}) int {
+	switch l := left.(type) {
+	case float64:
+		if r, ok := right.(float64); ok {
+			switch {
+			case l < r:
+				return -1
+			case l > r:
+				return 1
+			}
+			return 0
+		}
+	case string:
+		if r, ok := right.(string); ok {
+			if l == r {
+				return 0
+			}
+			if documentVersionPattern.MatchString(l) && documentVersionPattern.MatchString(r) {
+				if result := versionProcessor.NewVersionComparator().CompareVersions(l, r); result != 0 {
+					return result
+				}
+			}
+			return strings.Compare(l, r)
+		}
+	}
+	if DocumentValueString(left) == DocumentValueString(right) && fmt.Sprintf("%T", left) == fmt.Sprintf("%T", right) {
+		return 0
+	}
+	return 1
+}
+
+// documentVersionPattern 大小比较时按版本号比较的字符串
+var documentVersionPattern = regexp.MustCompile(`^v?\d+(\.\d+)*([-.+~]?[0-9A-Za-z.]*)?$`)
+
+// documentParser 路径和条件的解析器
+type documentParser struct {
+	input string
+	pos   int
+	// current 条件中表示当前元素的字符，JSONPath过滤器中为 @，select() 中为 .
+	current byte
+}
+
+// parsePath 解析路径，root为true时可以以 $ 开始，也可以直接以键名开始（兼容原来的点分隔路径）
+func (p *documentParser) parsePath(root bool) ([]documentSegment, error) {
+	var segments []documentSegment
+	if root && p.peek() == '$' {
+		p.pos++
+	}
+	if root && p.pos < len(p.input) && isDocumentKeyChar(p.input[p.pos]) {
+		segments = append(segments, documentSegment{kind: documentKeys, keys: []string{p.parseKey()}})
+	}
+
+	for p.pos < len(p.input) {
+		switch p.input[p.pos] {
+		case '.':
+			if strings.HasPrefix(p.input[p.pos:], "..") {
+				p.pos += 2
+				segments = append(segments, documentSegment{kind: documentRecursive})
+				if p.peek() == '[' {
+					continue
+				}
+			} else {
+				p.pos++
+			}
+			switch {
+			case p.peek() == '*':
+				p.pos++
+				segments = append(segments, documentSegment{kind: documentWildcard})
+			case p.peek() == '[':
+				// jq风格的 .["key"] 和 .[]
+			case p.pos < len(p.input) && isDocumentKeyChar(p.input[p.pos]):
+				segments = append(segments, documentSegment{kind: documentKeys, keys: []string{p.parseKey()}})
+			case p.peek() == '"':
+				key, err := p.parseString()
+				if err != nil {
+					return nil, err
+				}
+				segments = append(segments, documentSegment{kind: documentKeys, keys: []string{key}})
+			case len(segments) == 0 || segments[len(segments)-1].kind == documentRecursive:
+				// 单独的 . 表示当前值
+				if len(segments) > 0 {
+					return nil, fmt.Errorf("位置%d处的 .. 后面缺少键名", p.pos)
+				}
+			default:
+				return nil, fmt.Errorf("位置%d处的 . 后面缺少键名", p.pos)
+			}
+		case '[':
+			segment, err := p.parseBracket()
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, segment)
+		default:
+			return segments, nil
+		}
+	}
+	return segments, nil
+}
+
+// parseBracket 解析 [...]：下标、切片、通配符、带引号的键和过滤器
+func (p *documentParser) parseBracket() (documentSegment, error) {
+	p.pos++
+	p.skipSpace()
+	switch p.peek() {
+	case ']':
+		// jq风格的 [] 取全部元素
+		p.pos++
+		return documentSegment{kind: documentWildcard}, nil
+	case '*':
+		p.pos++
+		return documentSegment{kind: documentWildcard}, p.expectByte(']')
+	case '?':
+		p.pos++
+		p.skipSpace()
+		parenthesized := p.peek() == '('
+		if parenthesized {
+			p.pos++
+		}
+		condition, err := p.parseCondition()
+		if err != nil {
+			return documentSegment{}, err
+		}
+		if parenthesized {
+			if err := p.expectByte(')'); err != nil {
+				return documentSegment{}, err
+			}
+		}
+		return documentSegment{kind: documentFilter, filter: condition}, p.expectByte(']')
+	case '\'', '"':
+		segment := documentSegment{kind: documentKeys}
+		for {
+			key, err := p.parseString()
+			if err != nil {
+				return documentSegment{}, err
+			}
+			segment.keys = append(segment.keys, key)
+			p.skipSpace()
+			if p.peek() != ',' {
+				return segment, p.expectByte(']')
+			}
+			p.pos++
+			p.skipSpace()
+		}
+	}
+
+	// 下标列表或切片
+	segment := documentSegment{kind: documentIndexes}
+	first, hasFirst := p.parseInt()
+	p.skipSpace()
+	if p.peek() == ':' {
+		p.pos++
+		p.skipSpace()
+		segment = documentSegment{kind: documentSlice}
+		if hasFirst {
+			segment.sliceStart = &first
+		}
+		if end, ok := p.parseInt(); ok {
+			segment.sliceEnd = &end
+		}
+		p.skipSpace()
+		return segment, p.expectByte(']')
+	}
+	if !hasFirst {
+		return documentSegment{}, fmt.Errorf("位置%d处的 [ ] 中的内容无效", p.pos)
+	}
+	segment.indexes = append(segment.indexes, first)
+	for p.peek() == ',' {
+		p.pos++
+		p.skipSpace()
+		index, ok := p.parseInt()
+		if !ok {
+			return documentSegment{}, fmt.Errorf("位置%d处缺少下标", p.pos)
+		}
+		segment.indexes = append(segment.indexes, index)
+		p.skipSpace()
+	}
+	return segment, p.expectByte(']')
+}
+
+// parseCondition 解析 or 条件
+func (p *documentParser) parseCondition() (documentCondition, error) {
+	left, err := p.parseAndCondition()
+	for err == nil && p.consumeOperator("||", "or") {
+		var right documentCondition
+		right, err = p.parseAndCondition()
+		left = &documentOrCondition{left: left, right: right}
+	}
+	return left, err
+}
+
+// parseAndCondition 解析 and 条件
+func (p *documentParser) parseAndCondition() (documentCondition, error) {
+	left, err := p.parseUnaryCondition()
+	for err == nil && p.consumeOperator("&&", "and") {
+		var right documentCondition
+		right, err = p.parseUnaryCondition()
+		left = &documentAndCondition{left: left, right: right}
+	}
+	return left, err
+}
+
+// parseUnaryCondition 解析 !、not、括号和比较
+func (p *documentParser) parseUnaryCondition() (documentCondition, error) {
+	p.skipSpace()
+	if p.peek() == '!' && !strings.HasPrefix(p.input[p.pos:], "!=") {
+		p.pos++
+		operand, err := p.parseUnaryCondition()
+		return &documentNotCondition{operand: operand}, err
+	}
+	if p.consumeWord("not") {
+		operand, err := p.parseUnaryCondition()
+		return &documentNotCondition{operand: operand}, err
+	}
+	if p.peek() == '(' {
+		p.pos++
+		condition, err := p.parseCondition()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		return condition, p.expectByte(')')
+	}
+
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	condition := &documentCompareCondition{left: left}
+	p.skipSpace()
+	for _, op := range []string{"==", "!=", "<=", ">=", "=~", "<", ">"} {
+		if strings.HasPrefix(p.input[p.pos:], op) {
+			condition.op = op
+			p.pos += len(op)
+			break
+		}
+	}
+	if condition.op == "" {
+		if !left.isPath {
+			return nil, fmt.Errorf("位置%d处缺少比较运算符", p.pos)
+		}
+		return condition, nil
+	}
+
+	p.skipSpace()
+	if condition.op == "=~" {
+		pattern := ""
+		if p.peek() == '/' {
+			end := strings.IndexByte(p.input[p.pos+1:], '/')
+			if end < 0 {
+				return nil, fmt.Errorf("正则表达式缺少结束的 /")
+			}
+			pattern = p.input[p.pos+1 : p.pos+1+end]
+			p.pos += end + 2
+		} else {
+			pattern, err = p.parseString()
+			if err != nil {
+				return nil, err
+			}
+		}
+		if condition.regex, err = regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("编译正则表达式失败: %v", err)
+		}
+		return condition, nil
+	}
+	condition.right, err = p.parseOperand()
+	return condition, err
+}
+
+// parseOperand 解析相对路径或字面量
+func (p *documentParser) parseOperand() (documentOperand, error) {
+	p.skipSpace()
+	switch ch := p.peek(); {
+	case ch == p.current:
+		if p.current == '@' {
+			p.pos++
+		}
+		path, err := p.parsePath(false)
+		return documentOperand{path: path, isPath: true}, err
+	case ch == '\'' || ch == '"':
+		s, err := p.parseString()
+		return documentOperand{literal: s}, err
+	case ch == '-' || ch >= '0' && ch <= '9':
+		start := p.pos
+		p.pos++
+		for p.pos < len(p.input) && strings.IndexByte("0123456789.eE+-", p.input[p.pos]) >= 0 {
+			p.pos++
+		}
+		number, err := strconv.ParseFloat(p.input[start:p.pos], 64)
+		if err != nil {
+			return documentOperand{}, fmt.Errorf("无效的数字: %s", p.input[start:p.pos])
+		}
+		return documentOperand{literal: number}, nil
+	}
+	for word, literal := range map[string]interface{}{"true": true, "false": false, "null": nil} {
+		if p.consumeWord(word) {
+			return documentOperand{literal: literal}, nil
+		}
+	}
+	return documentOperand{}, fmt.Errorf("位置%d处缺少操作数", p.pos)
+}
+
+// parseKey 读取不带引号的键名
+func (p *documentParser) parseKey() string {
+	start := p.pos
+	for p.pos < len(p.input) && isDocumentKeyChar(p.input[p.pos]) {
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+// parseString 读取单引号或双引号字符串，支持反斜杠转义
+func (p *documentParser) parseString() (string, error) {
+	quote := p.peek()
+	if quote != '\'' && quote != '"' {
+		return "", fmt.Errorf("位置%d处缺少字符串", p.pos)
+	}
+	var builder strings.Builder
+	for i := p.pos + 1; i < len(p.input); i++ {
+		ch := p.input[i]
+		if ch == '\\' && i+1 < len(p.input) {
+			i++
+			builder.WriteByte(p.input[i])
+			continue
+		}
+		if ch == quote {
+			p.pos = i + 1
+			return builder.String(), nil
+		}
+		builder.WriteByte(ch)
+	}
+	return "", fmt.Errorf("字符串缺少结束引号")
+}
+
+// parseInt 读取整数
+func (p *documentParser) parseInt() (int, bool) {
+	start := p.pos
+	if p.peek() == '-' {
+		p.pos++
+	}
+	for p.pos < len(p.input) && p.input[p.pos] >= '0' && p.input[p.pos] <= '9' {
+		p.pos++
+	}
+	value, err := strconv.Atoi(p.input[start:p.pos])
+	if err != nil {
+		p.pos = start
+		return 0, false
+	}
+	return value, true
+}
+
+// consumeOperator 读取符号形式或单词形式的运算符
+func (p *documentParser) consumeOperator(symbol, word string) bool {
+	p.skipSpace()
+	if strings.HasPrefix(p.input[p.pos:], symbol) {
+		p.pos += len(symbol)
+		return true
+	}
+	return p.consumeWord(word)
+}
+
+// consumeWord 读取完整的单词，如 and，不匹配 android 的前缀
+func (p *documentParser) consumeWord(word string) bool {
+	if !strings.HasPrefix(p.input[p.pos:], word) {
+		return false
+	}
+	if end := p.pos + len(word); end < len(p.input) && isDocumentKeyChar(p.input[end]) {
+		return false
+	}
+	p.pos += len(word)
+	return true
+}
+
+// expectByte 读取指定的字符
+func (p *documentParser) expectByte(ch byte) error {
+	p.skipSpace()
+	if p.peek() != ch {
+		return fmt.Errorf("位置%d处缺少 %c", p.pos, ch)
+	}
+	p.pos++
+	return nil
+}
+
+// peek 返回当前字符，已到结尾时返回0
+func (p *documentParser) peek() byte {
+	if p.pos < len(p.input) {
+		return p.input[p.pos]
+	}
+	return 0
+}
+
+// skipSpace 跳过空白
+func (p *documentParser) skipSpace() {
+	for p.pos < len(p.input) && strings.IndexByte(" \t\r\n", p.input[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+// isDocumentKeyChar 检查字符是否可以出现在不带引号的键名中
+func isDocumentKeyChar(ch byte) bool {
+	return ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9' || ch == '_' || ch == '-' || ch >= 0x80
+}
+
+// splitTopLevel 按不在引号、括号中的分隔符拆分字符串
+func splitTopLevel(s string, sep byte) []string {
+	var parts []string
+	depth := 0
+	var quote byte
+	start := 0
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		switch {
+		case quote != 0:
+			if ch == '\\' {
+				i++
+			} else if ch == quote {
+				quote = 0
+			}
+		case ch == '\'' || ch == '"':
+			quote = ch
+		case ch == '(' || ch == '[':
+			depth++
+		case ch == ')' || ch == ']':
+			depth--
+		case ch == sep && depth == 0:
+			// || 是条件中的运算符，不是阶段分隔符
+			if sep == '|' && i+1 < len(s) && s[i+1] == '|' {
+				i++
+				continue
+			}
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
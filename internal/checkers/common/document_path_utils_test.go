@@ -0,0 +1,160 @@
+package common
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const documentPathTestJSON = `{
+	"tag_name": "v1.2.3",
+	"info": {"version": "2.0.1"},
+	"@scope/pkg": {"version": "4.0.0"},
+	"x y": {"v": "5.0.0"},
+	"v:1": "6.0.0",
+	"a|b": "7.0.0",
+	"last": "8.0.0",
+	"releases": [
+		{"version": "1.0.0", "channel": "stable", "downloads": 10},
+		{"version": "1.3.0", "channel": "stable", "downloads": 30},
+		{"version": "1.4.0-beta.1", "channel": "beta", "downloads": 5},
+		{"version": "1.2.9", "channel": "stable", "downloads": 20}
+	],
+	"versions": ["0.9", "0.10", "0.11rc1"],
+	"nested": [["x", "3.1.0"]],
+	"empty": []
+}`
+
+func decodeDocumentPathTestJSON(t *testing.T) interface{} {
+	t.Helper()
+	var document interface{}
+	if err := json.Unmarshal([]byte(documentPathTestJSON), &document); err != nil {
+		t.Fatalf("解析测试文档失败: %v", err)
+	}
+	return document
+}
+
+func TestDocumentPathEvaluate(t *testing.T) {
+	tests := []struct {
+		name              string
+		expr              string
+		includePrerelease bool
+		want              []string
+	}{
+		{"点分隔路径", "info.version", false, []string{"2.0.1"}},
+		{"顶层键", "tag_name", false, []string{"v1.2.3"}},
+		{"点分隔路径中的数组下标", "releases.1.version", false, []string{"1.3.0"}},
+		{"原来的键中包含@和/", "@scope/pkg.version", false, []string{"4.0.0"}},
+		{"原来的键中包含空格", "x y.v", false, []string{"5.0.0"}},
+		{"原来的键中包含冒号", "v:1", false, []string{"6.0.0"}},
+		{"原来的键中包含竖线", "a|b", false, []string{"7.0.0"}},
+		{"与函数同名的键", "last", false, []string{"8.0.0"}},
+		{"$开头的路径", "$.info.version", false, []string{"2.0.1"}},
+		{".开头的路径", ".info.version", false, []string{"2.0.1"}},
+		{"数组下标", "$.releases[0].version", false, []string{"1.0.0"}},
+		{"负数下标", "$.releases[-1].version", false, []string{"1.2.9"}},
+		{"多个下标", "releases[0,2].version", false, []string{"1.0.0", "1.4.0-beta.1"}},
+		{"切片", "releases[1:3].version", false, []string{"1.3.0", "1.4.0-beta.1"}},
+		{"省略开始的切片", "releases[:1].version", false, []string{"1.0.0"}},
+		{"负数切片", "releases[-2:].version", false, []string{"1.4.0-beta.1", "1.2.9"}},
+		{"嵌套数组下标", "nested[0][1]", false, []string{"3.1.0"}},
+		{"带引号的键", `$["info"]['version']`, false, []string{"2.0.1"}},
+		{"jq风格带引号的键", `."@scope/pkg".version`, false, []string{"4.0.0"}},
+		{"通配符", "releases[*].channel", false, []string{"stable", "stable", "beta", "stable"}},
+		{"jq风格通配符", ".releases[].channel", false, []string{"stable", "stable", "beta", "stable"}},
+		{"递归查找", "$..version | max_version", false, []string{"4.0.0"}},
+		{"过滤器", "releases[?(@.channel=='beta')].version", false, []string{"1.4.0-beta.1"}},
+		{"不带括号的过滤器", "releases[?@.downloads > 15].version", false, []string{"1.3.0", "1.2.9"}},
+		{"过滤器中的与运算", "releases[?(@.channel=='stable' && @.downloads < 25)].version", false, []string{"1.0.0", "1.2.9"}},
+		{"过滤器中的或运算", "releases[?(@.downloads == 5 || @.downloads == 10)].version", false, []string{"1.0.0", "1.4.0-beta.1"}},
+		{"过滤器中的非运算", "releases[?(!(@.channel=='stable'))].version", false, []string{"1.4.0-beta.1"}},
+		{"过滤器中的正则", "releases[?(@.version =~ /^1\\.[23]/)].version", false, []string{"1.3.0", "1.2.9"}},
+		{"过滤器中的版本比较", "releases[?(@.version >= '1.2.10')].version", false, []string{"1.3.0", "1.4.0-beta.1"}},
+		{"过滤器中字段存在", "releases[?(@.downloads > 0 && @.channel)].channel", false, []string{"stable", "stable", "beta", "stable"}},
+		{"过滤器中字段不存在", "releases[?(@.missing)].version", false, nil},
+		{"select", `.releases[] | select(.channel == "stable") | .version`, false, []string{"1.0.0", "1.3.0", "1.2.9"}},
+		{"max_version", "releases[*].version | max_version", false, []string{"1.3.0"}},
+		{"max_version包括测试版本", "releases[*].version | max_version", true, []string{"1.4.0-beta.1"}},
+		{"过滤后max_version", "releases[?(@.channel=='stable')].version | max_version", true, []string{"1.3.0"}},
+		{"max_version展开数组", "versions | max_version", false, []string{"0.10"}},
+		{"select后max_version", `.releases[] | select(.channel == "stable") | .version | max_version`, false, []string{"1.3.0"}},
+		{"first取数组的第一个元素", "releases | first | .version", false, []string{"1.0.0"}},
+		{"last取数组的最后一个元素", "releases | last | .version", false, []string{"1.2.9"}},
+		{"last用于每个数组", "nested[*] | last", false, []string{"3.1.0"}},
+		{"first用于非数组", "info | first", false, nil},
+		{"first用于空数组", "empty | first", false, nil},
+		{"不存在的键", "missing.key", false, nil},
+		{"无法按表达式解析且不存在的原来的键", "missing[", false, nil},
+		{"越界的下标", "releases[10].version", false, nil},
+	}
+
+	document := decodeDocumentPathTestJSON(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := CompileDocumentPath(tt.expr)
+			if err != nil {
+				t.Fatalf("CompileDocumentPath(%q) 返回错误: %v", tt.expr, err)
+			}
+			values, err := path.Evaluate(document, tt.includePrerelease)
+			if err != nil {
+				t.Fatalf("Evaluate(%q) 返回错误: %v", tt.expr, err)
+			}
+			var got []string
+			for _, value := range values {
+				got = append(got, DocumentValueString(value))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Evaluate(%q) = %q，期望 %q", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDocumentPathReducerErrors(t *testing.T) {
+	document := decodeDocumentPathTestJSON(t)
+	for _, expr := range []string{
+		"releases[?(@.channel=='beta')].version | max_version",
+		"missing | max_version",
+	} {
+		path, err := CompileDocumentPath(expr)
+		if err != nil {
+			t.Fatalf("CompileDocumentPath(%q) 返回错误: %v", expr, err)
+		}
+		if values, err := path.Evaluate(document, false); err == nil {
+			t.Errorf("Evaluate(%q) = %v，期望返回错误", expr, values)
+		}
+	}
+}
+
+func TestCompileDocumentPathErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"$.releases[",
+		"$.releases[?(@.channel=='",
+		".releases[] | select(.channel ==)",
+		"$.releases[a]",
+		".info..",
+		"$.releases[0] | ",
+		"$.releases[?(@.version =~ '(')]",
+	} {
+		if _, err := CompileDocumentPath(expr); err == nil {
+			t.Errorf("CompileDocumentPath(%q) 期望返回错误", expr)
+		}
+	}
+}
+
+func TestNormalizeDocument(t *testing.T) {
+	document := NormalizeDocument(map[string]interface{}{
+		"int":    int64(3),
+		"yaml":   map[interface{}]interface{}{1: "a"},
+		"tables": []map[string]interface{}{{"version": int(2)}},
+	})
+	want := map[string]interface{}{
+		"int":    float64(3),
+		"yaml":   map[string]interface{}{"1": "a"},
+		"tables": []interface{}{map[string]interface{}{"version": float64(2)}},
+	}
+	if !reflect.DeepEqual(document, want) {
+		t.Errorf("NormalizeDocument = %#v，期望 %#v", document, want)
+	}
+}
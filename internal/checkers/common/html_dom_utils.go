@@ -0,0 +1,204 @@
+package common
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/andybalholm/cascadia"
+	"github.com/antchfx/htmlquery"
+	"github.com/antchfx/xpath"
+	"golang.org/x/net/html"
+
+	versionProcessor "aur-update-checker/internal/checkers/version"
+	"aur-update-checker/internal/logger"
+)
+
+const (
+	// SelectorPrefixCSS 版本提取关键字的CSS选择器前缀，如 css:div.release > h2、css:a.download::attr(href)
+	SelectorPrefixCSS = "css:"
+	// SelectorPrefixXPath 版本提取关键字的XPath前缀，如 xpath://span[@class='version']、xpath://a[contains(@href,'.deb')]/@href
+	SelectorPrefixXPath = "xpath:"
+)
+
+// cssValueSuffixPattern 匹配CSS选择器末尾的 ::attr(名称) 或 ::text，选择器本身不支持这两个伪元素
+var cssValueSuffixPattern = regexp.MustCompile(`::(?:attr\(\s*([^()\s]+)\s*\)|text)\s*$`)
+
+// selectedVersionPattern 匹配选取值中的版本号，保留 -beta.1、rc1 等测试版本后缀，由检查器根据是否检查测试版本过滤
+var selectedVersionPattern = regexp.MustCompile(`(?i)(?:^|[^0-9a-z.])v?(\d+(?:\.\d+)+(?:[-.~]?(?:alpha|beta|rc|preview|pre|dev)(?:\.?\d+)?)?)`)
+
+// IsSelectorKey 检查版本提取关键字是否使用CSS选择器或XPath
+func IsSelectorKey(key string) bool {
+	return strings.HasPrefix(key, SelectorPrefixCSS) || strings.HasPrefix(key, SelectorPrefixXPath)
+}
+
+// SelectHTMLValues 把HTML解析为DOM树，按 css: 或 xpath: 前缀的选择器选取节点，返回各节点的文本或属性值
+// CSS选择器默认取元素文本，以 ::attr(名称) 结尾时取属性值；XPath选取元素时取元素文本，选取 @属性 或 text() 时取对应的值，
+// 表达式的结果是字符串时（如 substring-after(//h1, 'v')）直接使用
+func SelectHTMLValues(content, key string) ([]string, error) {
+	document, err := html.Parse(strings.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("解析HTML失败: %v", err)
+	}
+
+	switch {
+	case strings.HasPrefix(key, SelectorPrefixCSS):
+		return selectCSSValues(document, strings.TrimSpace(strings.TrimPrefix(key, SelectorPrefixCSS)))
+	case strings.HasPrefix(key, SelectorPrefixXPath):
+		return selectXPathValues(document, strings.TrimSpace(strings.TrimPrefix(key, SelectorPrefixXPath)))
+	}
+	return nil, fmt.Errorf("版本提取关键字不是选择器: %s", key)
+}
+
+// ExtractSelectorVersions 按选择器选取节点，从每个节点的值中提取版本号，结果中包括测试版本
+func ExtractSelectorVersions(content, key string) ([]string, error) {
+	values, err := SelectHTMLValues(content, key)
+	if err != nil {
+		return nil, err
+	}
+	logger.GlobalLogger.Debugf("[selector] 选择器 %s 选取到 %d 个节点", key, len(values))
+	if len(values) == 0 {
+		return nil, fmt.Errorf("选择器未选取到任何节点: %s", key)
+	}
+
+	var versions []string
+	for _, value := range values {
+		if version := extractSelectedVersion(value); version != "" {
+			versions = append(versions, version)
+		}
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("无法从选择器选取的%d个节点中提取版本号", len(values))
+	}
+	return versions, nil
+}
+
+// FilterSelectorVersions 过滤选择器提取到的版本，checkTestVersion 不为 1 时跳过测试版本，只剩测试版本时返回错误
+func FilterSelectorVersions(versions []string, checkTestVersion int) ([]string, error) {
+	if checkTestVersion == 1 {
+		return versions, nil
+	}
+	comparator := versionProcessor.NewVersionComparator()
+	var stable []string
+	for _, version := range versions {
+		if !comparator.IsStableVersion(version) {
+			logger.GlobalLogger.Debugf("[selector] 跳过测试版本: %s", version)
+			continue
+		}
+		stable = append(stable, version)
+	}
+	if len(stable) == 0 {
+		return nil, fmt.Errorf("选择器只选取到测试版本: %v", versions)
+	}
+	return stable, nil
+}
+
+// LatestSelectorVersion 返回选择器提取到的版本中最新的版本，checkTestVersion 不为 1 时跳过测试版本
+func LatestSelectorVersion(versions []string, checkTestVersion int) (string, error) {
+	versions, err := FilterSelectorVersions(versions, checkTestVersion)
+	if err != nil {
+		return "", err
+	}
+	comparator := versionProcessor.NewVersionComparator()
+	latest := versions[0]
+	for _, version := range versions[1:] {
+		if comparator.CompareVersions(version, latest) > 0 {
+			latest = version
+		}
+	}
+	return latest, nil
+}
+
+// extractSelectedVersion 从选取的值中提取第一个版本号，值中没有点分隔的版本号时使用 ExtractVersionFromString
+func extractSelectedVersion(value string) string {
+	if match := selectedVersionPattern.FindStringSubmatch(value); match != nil {
+		return match[1]
+	}
+	return ExtractVersionFromString(value)
+}
+
+// selectCSSValues 按CSS选择器选取元素，返回元素文本（::text 相同）或 ::attr() 指定的属性值，没有该属性的元素不产生结果
+func selectCSSValues(document *html.Node, selector string) ([]string, error) {
+	attr := ""
+	if match := cssValueSuffixPattern.FindStringSubmatchIndex(selector); match != nil {
+		if match[2] >= 0 {
+			attr = strings.ToLower(selector[match[2]:match[3]])
+		}
+		selector = strings.TrimSpace(selector[:match[0]])
+	}
+	if selector == "" {
+		return nil, fmt.Errorf("解析CSS选择器失败: ::attr() 和 ::text 前面缺少选择器")
+	}
+
+	compiled, err := cascadia.ParseGroup(selector)
+	if err != nil {
+		return nil, fmt.Errorf("解析CSS选择器失败: %v", err)
+	}
+
+	var values []string
+	for _, node := range cascadia.QueryAll(document, compiled) {
+		if attr == "" {
+			values = append(values, collapseHTMLSpace(htmlTextContent(node)))
+		} else if htmlquery.ExistsAttr(node, attr) {
+			values = append(values, strings.TrimSpace(htmlquery.SelectAttr(node, attr)))
+		}
+	}
+	return values, nil
+}
+
+// selectXPathValues 按XPath选取节点或计算表达式
+func selectXPathValues(document *html.Node, expr string) ([]string, error) {
+	compiled, err := xpath.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("解析XPath失败: %v", err)
+	}
+
+	switch result := compiled.Evaluate(htmlquery.CreateXPathNavigator(document)).(type) {
+	case *xpath.NodeIterator:
+		var values []string
+		for result.MoveNext() {
+			navigator := result.Current().(*htmlquery.NodeNavigator)
+			switch navigator.NodeType() {
+			case xpath.ElementNode, xpath.RootNode:
+				values = append(values, collapseHTMLSpace(htmlTextContent(navigator.Current())))
+			default:
+				// 属性、文本和注释节点
+				values = append(values, strings.TrimSpace(navigator.Value()))
+			}
+		}
+		return values, nil
+	case string:
+		return []string{strings.TrimSpace(result)}, nil
+	case float64:
+		return []string{strconv.FormatFloat(result, 'f', -1, 64)}, nil
+	case bool:
+		return []string{strconv.FormatBool(result)}, nil
+	}
+	return nil, nil
+}
+
+// htmlTextContent 返回节点及其后代的文本，script和style中的内容除外
+func htmlTextContent(node *html.Node) string {
+	var builder strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		switch {
+		case n.Type == html.TextNode:
+			builder.WriteString(n.Data)
+			return
+		case n.Type == html.ElementNode && (n.Data == "script" || n.Data == "style"):
+			return
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(node)
+	return builder.String()
+}
+
+// collapseHTMLSpace 合并文本中的空白
+func collapseHTMLSpace(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
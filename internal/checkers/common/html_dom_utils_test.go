@@ -0,0 +1,142 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+)
+
+const selectorTestHTML = `<!DOCTYPE html>
+<html>
+<head><title>Downloads</title><script>var version = "9.9.9";</script></head>
+<body>
+	<div class="release latest">
+		<h2>Version 2.4.1</h2>
+		<p>Released <span class="date">2024-05-01</span></p>
+		<a class="download" href="/files/app_2.4.1_amd64.deb">Debian</a>
+		<a class="download" href="/files/app-2.4.1.tar.gz">Source</a>
+	</div>
+	<div class="release">
+		<h2>Version 2.3.0</h2>
+		<a class="download" href="/files/app_2.3.0_amd64.deb">Debian</a>
+	</div>
+	<span class="version" data-version="v3.0.0-rc1">3.0.0 RC 1</span>
+	<h1>Version 2.4.1</h1>
+	<table id="versions">
+		<tr><td>stable</td><td>2.4.1</td></tr>
+		<tr><td>beta</td><td>3.0.0-beta.2</td></tr>
+	</table>
+	<ul><li>1.0<li>1.1<li>1.2</ul>
+</body>
+</html>`
+
+func TestSelectHTMLValues(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+		want []string
+	}{
+		{"CSS子元素", "css:div.release > h2", []string{"Version 2.4.1", "Version 2.3.0"}},
+		{"CSS多个类", "css:div.release.latest h2", []string{"Version 2.4.1"}},
+		{"CSS属性值", "css:a.download::attr(href)", []string{"/files/app_2.4.1_amd64.deb", "/files/app-2.4.1.tar.gz", "/files/app_2.3.0_amd64.deb"}},
+		{"CSS属性选择器", `css:a[href$=".deb"]::attr(href)`, []string{"/files/app_2.4.1_amd64.deb", "/files/app_2.3.0_amd64.deb"}},
+		{"CSS没有该属性的元素", "css:h2::attr(href)", nil},
+		{"CSS ::text", "css:span.version::text", []string{"3.0.0 RC 1"}},
+		{"CSS伪类", "css:div.release:first-child h2", []string{"Version 2.4.1"}},
+		{"CSS nth-child", "css:#versions tr:nth-child(2) td:last-child", []string{"3.0.0-beta.2"}},
+		{"CSS not", "css:div.release:not(.latest) h2", []string{"Version 2.3.0"}},
+		{"CSS contains", `css:tr:contains("stable") td:nth-child(2)`, []string{"2.4.1"}},
+		{"CSS选择器组", "css:h1, span.version", []string{"3.0.0 RC 1", "Version 2.4.1"}},
+		{"CSS隐式结束的li", "css:ul > li:last-child", []string{"1.2"}},
+		{"CSS文本不包括script", "css:head", []string{"Downloads"}},
+		{"XPath元素文本", "xpath://span[@class='version']", []string{"3.0.0 RC 1"}},
+		{"XPath属性", "xpath://a[contains(@href,'.deb')]/@href", []string{"/files/app_2.4.1_amd64.deb", "/files/app_2.3.0_amd64.deb"}},
+		{"XPath text()", "xpath://div[contains(@class,'latest')]/h2/text()", []string{"Version 2.4.1"}},
+		{"XPath字符串结果", "xpath:substring-after(//h1, 'Version ')", []string{"2.4.1"}},
+		{"XPath隐式tbody", "xpath://table[@id='versions']/tbody/tr[td[1]='beta']/td[2]", []string{"3.0.0-beta.2"}},
+		{"XPath过滤表达式", "xpath:(//div[@class='release']/h2)[1]", []string{"Version 2.3.0"}},
+		{"XPath数字结果", "xpath:count(//a[@class='download'])", []string{"3"}},
+		{"未选取到节点", "css:div.missing", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SelectHTMLValues(selectorTestHTML, tt.key)
+			if err != nil {
+				t.Fatalf("SelectHTMLValues(%q) 返回错误: %v", tt.key, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SelectHTMLValues(%q) = %q，期望 %q", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectHTMLValuesErrors(t *testing.T) {
+	for _, key := range []string{
+		"css:div[",
+		"css:::attr(href)",
+		"xpath://div[",
+		"xpath:unknown-function(//a)",
+		"div.release",
+	} {
+		if values, err := SelectHTMLValues(selectorTestHTML, key); err == nil {
+			t.Errorf("SelectHTMLValues(%q) = %q，期望返回错误", key, values)
+		}
+	}
+}
+
+func TestExtractSelectorVersions(t *testing.T) {
+	tests := []struct {
+		key     string
+		want    []string
+		wantErr bool
+	}{
+		{"css:div.release > h2", []string{"2.4.1", "2.3.0"}, false},
+		{"xpath://a[contains(@href,'.tar.gz')]/@href", []string{"2.4.1"}, false},
+		{"xpath://a[contains(@href,'.deb')]/@href", []string{"2.4.1", "2.3.0"}, false},
+		{"css:span.version::attr(data-version)", []string{"3.0.0-rc1"}, false},
+		{"css:#versions td:nth-child(2)", []string{"2.4.1", "3.0.0-beta.2"}, false},
+		{"css:div.missing", nil, true},
+		{"css:title", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := ExtractSelectorVersions(selectorTestHTML, tt.key)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ExtractSelectorVersions(%q) 错误 = %v，期望返回错误: %v", tt.key, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ExtractSelectorVersions(%q) = %q，期望 %q", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestSelectorVersionFiltering(t *testing.T) {
+	versions := []string{"1.9.2", "2.0.0-beta.1", "1.10.0", "2.0.0-alpha.1"}
+	tests := []struct {
+		versions         []string
+		checkTestVersion int
+		want             string
+		wantErr          bool
+	}{
+		{versions, 0, "1.10.0", false},
+		{versions, 1, "2.0.0-beta.1", false},
+		{versions, 2, "1.10.0", false},
+		{[]string{"3.0.0-alpha.2"}, 0, "", true},
+		{[]string{"3.0.0-alpha.2"}, 1, "3.0.0-alpha.2", false},
+	}
+	for _, tt := range tests {
+		got, err := LatestSelectorVersion(tt.versions, tt.checkTestVersion)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("LatestSelectorVersion(%q, %d) 错误 = %v，期望返回错误: %v", tt.versions, tt.checkTestVersion, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("LatestSelectorVersion(%q, %d) = %q，期望 %q", tt.versions, tt.checkTestVersion, got, tt.want)
+		}
+	}
+
+	if stable, err := FilterSelectorVersions(versions, 0); err != nil || !reflect.DeepEqual(stable, []string{"1.9.2", "1.10.0"}) {
+		t.Errorf("FilterSelectorVersions(%q, 0) = %q, %v，期望 [1.9.2 1.10.0]", versions, stable, err)
+	}
+}
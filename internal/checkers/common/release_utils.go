@@ -0,0 +1,49 @@
+package common
+
+import (
+	"path"
+	"strings"
+)
+
+// OptionAsset 软件包检查器选项：发布附件匹配模式，如 *_amd64.deb，多个模式用逗号分隔
+const OptionAsset = "asset"
+
+// ReleaseAsset 发布附件信息
+type ReleaseAsset struct {
+	Name        string `json:"name"`
+	DownloadURL string `json:"downloadUrl"`
+	Size        int64  `json:"size"` // 平台未提供大小时为0
+	SHA256      string `json:"sha256,omitempty"`  // 上游提供的附件校验和，十六进制编码
+	SHA512      string `json:"sha512,omitempty"`  // 上游提供的附件校验和，base64编码
+	Default     bool   `json:"default,omitempty"` // 上游指定的主文件，未配置附件匹配模式时使用
+}
+
+// MatchReleaseAsset 按匹配模式查找发布附件，模式按顺序尝试，返回第一个匹配的附件
+// 模式使用shell通配符语法匹配附件名称或下载地址中的文件名，不区分大小写
+func MatchReleaseAsset(assets []ReleaseAsset, patterns string) *ReleaseAsset {
+	for _, pattern := range strings.Split(patterns, ",") {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if pattern == "" {
+			continue
+		}
+		for i := range assets {
+			// GitLab的附件名称是显示名称，不一定是文件名，因此同时匹配下载地址中的文件名
+			for _, name := range []string{assets[i].Name, path.Base(assets[i].DownloadURL)} {
+				if matched, err := path.Match(pattern, strings.ToLower(name)); err == nil && matched {
+					return &assets[i]
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// DefaultReleaseAsset 返回上游指定的主文件附件，没有时返回nil
+func DefaultReleaseAsset(assets []ReleaseAsset) *ReleaseAsset {
+	for i := range assets {
+		if assets[i].Default {
+			return &assets[i]
+		}
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package common
 
 import (
+	stderrors "errors"
 	"fmt"
 	"net/url"
 	"time"
@@ -94,6 +95,16 @@ func NewUnsupportedError(url, message string) *CheckerError {
 	}
 }
 
+// NewRateLimitError 创建频率限制错误，resetAt为限制解除的时间
+func NewRateLimitError(url string, resetAt time.Time) *CheckerError {
+	appErr := errors.NewAppError(errors.RateLimitError, fmt.Sprintf("API请求次数已用尽，将于 %s 重置", resetAt.Format("2006-01-02 15:04:05")), nil)
+	appErr.WithRetryable(true).WithBackoff(time.Until(resetAt)).WithContext("reset_at", resetAt)
+	return &CheckerError{
+		AppError: appErr,
+		URL:      url,
+	}
+}
+
 // ValidateURL 验证URL格式是否正确
 func ValidateURL(urlStr string) (*url.URL, error) {
 	if urlStr == "" {
@@ -175,6 +186,25 @@ func IsUnsupportedError(err error) bool {
 	return false
 }
 
+// IsRateLimitError 检查是否为频率限制错误，支持被fmt.Errorf("%w")包装的错误
+func IsRateLimitError(err error) bool {
+	var checkerErr *CheckerError
+	if stderrors.As(err, &checkerErr) && checkerErr.AppError != nil {
+		return checkerErr.AppError.Code == errors.RateLimitError
+	}
+	return false
+}
+
+// GetRateLimitReset 获取频率限制错误中的重置时间
+func GetRateLimitReset(err error) (time.Time, bool) {
+	var checkerErr *CheckerError
+	if !stderrors.As(err, &checkerErr) || checkerErr.AppError == nil {
+		return time.Time{}, false
+	}
+	resetAt, ok := checkerErr.AppError.Context["reset_at"].(time.Time)
+	return resetAt, ok
+}
+
 // IsRetryableError 检查错误是否可重试
 func IsRetryableError(err error) bool {
 	if checkerErr, ok := err.(*CheckerError); ok {
@@ -2,7 +2,11 @@ package common
 
 import (
 	"context"
+	"strings"
 	"sync"
+	"time"
+
+	versionProcessor "aur-update-checker/internal/checkers/version"
 )
 
 // UpstreamChecker 上游检查器接口
@@ -24,6 +28,73 @@ type UpstreamChecker interface {
 
 	// CheckWithVersionRef 带选项和版本引用地检查上游版本
 	CheckWithVersionRef(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (string, error)
+
+	// CheckWithResult 带选项和版本引用地检查上游版本，返回包含发布时间等信息的完整结果
+	// 返回字符串的检查方法只取结果中的版本号
+	CheckWithResult(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (*UpstreamCheckResult, error)
+}
+
+// UpstreamCheckResult 上游检查结果
+type UpstreamCheckResult struct {
+	Version      string         `json:"version"`
+	TagName      string         `json:"tagName,omitempty"`
+	IsPrerelease bool           `json:"isPrerelease"`
+	ReleaseDate  time.Time      `json:"releaseDate"`         // 上游未提供发布时间时为零值
+	SourceURL    string         `json:"sourceUrl,omitempty"` // 版本信息的来源页面，如发布页面
+	Assets       []ReleaseAsset `json:"assets,omitempty"`
+}
+
+// NewVersionResult 为只能获取版本号的检查器创建检查结果，预发布标志根据版本号判断
+func NewVersionResult(version, sourceURL string) *UpstreamCheckResult {
+	return &UpstreamCheckResult{
+		Version:      version,
+		IsPrerelease: !versionProcessor.NewVersionComparator().IsStableVersion(version),
+		SourceURL:    sourceURL,
+	}
+}
+
+// releaseDateLayouts 上游返回的时间格式：API常用的RFC 3339，RSS的RFC 1123（日期可以是一位数），以及MetaCPAN等不带时区的UTC时间
+var releaseDateLayouts = []string{
+	time.RFC3339, time.RFC1123Z, time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 -0700", "Mon, 2 Jan 2006 15:04:05 MST",
+	"2006-01-02T15:04:05",
+}
+
+// ParseReleaseDate 解析上游API返回的RFC 3339格式时间或RSS中的RFC 1123格式时间，无法解析时返回零值
+func ParseReleaseDate(value string) time.Time {
+	value = strings.TrimSpace(value)
+	// SourceForge等RSS使用RFC 822中的 UT 表示UTC，Go无法解析两个字母的时区缩写
+	if strings.HasSuffix(value, " UT") {
+		value += "C"
+	}
+	for _, layout := range releaseDateLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed
+		}
+	}
+	return time.Time{}
+}
+
+// BatchCheckRequest 批量检查中的单个检查请求
+type BatchCheckRequest struct {
+	URL               string
+	VersionExtractKey string
+	VersionRef        string
+	CheckTestVersion  int
+	Options           CheckOptions
+}
+
+// BatchCheckResult 批量检查中的单个检查结果
+type BatchCheckResult struct {
+	Result *UpstreamCheckResult
+	Err    error
+}
+
+// BatchUpstreamChecker 支持在一次请求中检查多个上游的检查器
+type BatchUpstreamChecker interface {
+	// CheckBatch 批量检查上游版本，返回的结果与请求按下标一一对应
+	// 单个结果的Err表示该上游检查失败，调用方可以改用普通检查方法重试
+	CheckBatch(ctx context.Context, requests []BatchCheckRequest) []BatchCheckResult
 }
 
 // UpstreamCheckerRegistry 上游检查器注册器，用于管理所有可用的检查器
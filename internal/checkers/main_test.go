@@ -0,0 +1,23 @@
+package checkers
+
+import (
+	"os"
+	"testing"
+
+	"aur-update-checker/internal/logger"
+)
+
+// TestMain 在临时目录中重新初始化日志，测试环境只输出警告以上的日志
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "aur-update-checker-test-")
+	if err != nil {
+		panic(err)
+	}
+	os.Setenv("XDG_CONFIG_HOME", dir)
+	os.Setenv("AUR_ENV", "testing")
+	logger.InitLogger()
+
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
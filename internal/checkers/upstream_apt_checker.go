@@ -0,0 +1,390 @@
+package checkers
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"aur-update-checker/internal/checkers/common"
+	versionProcessor "aur-update-checker/internal/checkers/version"
+	"aur-update-checker/internal/logger"
+
+	checkerInterfaces "aur-update-checker/internal/interfaces/checkers"
+)
+
+const (
+	// OptionPackage 软件包检查器选项：仓库中的软件包名称，未设置时使用版本提取关键字
+	OptionPackage = "package"
+	// OptionSuite 软件包检查器选项：APT仓库的发行版代号，如 stable、jammy
+	OptionSuite = "suite"
+	// OptionComponent 软件包检查器选项：APT仓库的组件，如 main
+	OptionComponent = "component"
+	// OptionArch 软件包检查器选项：软件包架构，如 amd64、arm64
+	OptionArch = "arch"
+
+	defaultAptSuite     = "stable"
+	defaultAptComponent = "main"
+	defaultAptArch      = "amd64"
+
+	// aptDistsDir APT仓库索引文件所在的目录
+	aptDistsDir = "/dists/"
+)
+
+// AptPackage Packages索引中的软件包条目
+type AptPackage struct {
+	Package  string
+	Version  string
+	Filename string
+	Size     int64
+	SHA256   string
+}
+
+// AptChecker Debian/APT仓库检查器
+// 从 dists/<suite>/<component>/binary-<arch>/Packages(.gz|.xz) 索引中查找软件包的最新版本，
+// 适用于只提供APT仓库的厂商软件，如Microsoft、Google的Linux软件包
+type AptChecker struct {
+	*checkerInterfaces.BaseChecker
+	client *http.Client
+}
+
+// NewAptChecker 创建APT仓库检查器
+func NewAptChecker() *AptChecker {
+	return &AptChecker{
+		BaseChecker: checkerInterfaces.NewBaseChecker("apt"),
+		client:      &http.Client{},
+	}
+}
+
+// Supports 检查URL是否是APT仓库的dists目录地址或Packages索引地址
+// 仓库根地址无法与普通网页区分，需要在软件包中指定使用apt检查器
+func (c *AptChecker) Supports(url string) bool {
+	lower := strings.ToLower(strings.TrimSuffix(url, "/"))
+	return strings.Contains(lower, aptDistsDir) || isAptIndexURL(lower)
+}
+
+// isAptIndexURL 检查小写的URL是否是Packages、Packages.gz或Packages.xz索引地址
+func isAptIndexURL(lower string) bool {
+	return strings.HasSuffix(lower, "/packages") ||
+		strings.HasSuffix(lower, "/packages.gz") ||
+		strings.HasSuffix(lower, "/packages.xz")
+}
+
+// Priority 仓库索引中的版本很准确，优先级高于通用HTTP检查器
+func (c *AptChecker) Priority() int {
+	return 65
+}
+
+// Check 实现检查器接口，获取APT仓库中软件包的最新版本
+func (c *AptChecker) Check(ctx context.Context, url, versionExtractKey string) (string, error) {
+	// 默认不检查测试版本
+	return c.CheckWithOption(ctx, url, versionExtractKey, 0)
+}
+
+// CheckWithOption 实现检查器接口，根据选项获取APT仓库中软件包的最新版本
+func (c *AptChecker) CheckWithOption(ctx context.Context, url, versionExtractKey string, checkTestVersion int) (string, error) {
+	return c.CheckWithVersionRef(ctx, url, versionExtractKey, "", checkTestVersion)
+}
+
+// CheckWithVersionRef 带选项和版本引用地检查上游版本
+func (c *AptChecker) CheckWithVersionRef(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (string, error) {
+	result, err := c.CheckWithResult(ctx, url, versionExtractKey, versionRef, checkTestVersion)
+	if err != nil {
+		return "", err
+	}
+	return result.Version, nil
+}
+
+// CheckWithResult 实现检查器接口，返回软件包的最新版本以及deb文件的下载地址和SHA256
+// URL可以是仓库根地址（dists目录的上级目录）或Packages索引文件地址
+func (c *AptChecker) CheckWithResult(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (*common.UpstreamCheckResult, error) {
+	options := common.CheckOptionsFromContext(ctx)
+	packageName := options.Get(OptionPackage, versionExtractKey)
+	if packageName == "" {
+		return nil, fmt.Errorf("未指定软件包名称，请在版本提取关键字或检查器选项 package 中设置")
+	}
+
+	repoURL, indexURLs := c.indexURLs(url, options)
+	logger.GlobalLogger.Debugf("[apt] 开始检查APT仓库 - 仓库: %s, 软件包: %s", repoURL, packageName)
+
+	var packages []AptPackage
+	var indexURL string
+	var err error
+	for _, indexURL = range indexURLs {
+		packages, err = c.fetchPackages(ctx, indexURL, packageName)
+		if err == nil {
+			break
+		}
+		logger.GlobalLogger.Debugf("[apt] 获取索引失败(%s): %v", indexURL, err)
+	}
+	if err != nil {
+		logger.GlobalLogger.Errorf("[apt] 获取Packages索引失败: %v", err)
+		return nil, fmt.Errorf("获取Packages索引失败: %v", err)
+	}
+
+	pkg, version := c.selectPackage(packages, checkTestVersion)
+	if pkg == nil {
+		logger.GlobalLogger.Errorf("[apt] 索引中未找到软件包 %s", packageName)
+		return nil, fmt.Errorf("索引 %s 中未找到软件包 %s", indexURL, packageName)
+	}
+
+	result := &common.UpstreamCheckResult{
+		Version:      c.BaseChecker.NormalizeVersionWithOption(version, checkTestVersion),
+		TagName:      pkg.Version,
+		IsPrerelease: !versionProcessor.NewVersionComparator().IsStableVersion(version),
+		SourceURL:    indexURL,
+	}
+	if pkg.Filename != "" {
+		result.Assets = []common.ReleaseAsset{{
+			Name:        path.Base(pkg.Filename),
+			DownloadURL: repoURL + "/" + strings.TrimPrefix(pkg.Filename, "/"),
+			Size:        pkg.Size,
+			SHA256:      pkg.SHA256,
+			Default:     true,
+		}}
+	}
+
+	logger.GlobalLogger.Infof("[apt] 软件包 %s 的最新版本: %s (%s)", packageName, result.Version, pkg.Version)
+	return result, nil
+}
+
+// indexURLs 返回仓库根地址和按顺序尝试的Packages索引地址
+// Filename字段是相对仓库根目录的路径，因此直接给出索引地址时，仓库根地址取dists目录的上级目录
+func (c *AptChecker) indexURLs(url string, options common.CheckOptions) (string, []string) {
+	url = strings.TrimSuffix(strings.TrimSpace(url), "/")
+
+	if isAptIndexURL(strings.ToLower(url)) {
+		repoURL := url
+		if i := strings.Index(url, aptDistsDir); i >= 0 {
+			repoURL = url[:i]
+		}
+		return repoURL, []string{url}
+	}
+
+	// 地址中已包含dists目录时，以其上级目录作为仓库根地址
+	if i := strings.Index(url, aptDistsDir); i >= 0 {
+		url = url[:i]
+	}
+
+	indexURL := fmt.Sprintf("%s/dists/%s/%s/binary-%s/Packages",
+		url,
+		options.Get(OptionSuite, defaultAptSuite),
+		options.Get(OptionComponent, defaultAptComponent),
+		options.Get(OptionArch, defaultAptArch))
+	return url, []string{indexURL + ".gz", indexURL + ".xz", indexURL}
+}
+
+// fetchPackages 下载Packages索引，返回指定软件包的所有条目
+// 索引文件可能有几十MB，因此边下载边解析，不读入内存
+func (c *AptChecker) fetchPackages(ctx context.Context, indexURL, packageName string) ([]AptPackage, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", indexURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %v", err)
+	}
+	req.Header.Set("User-Agent", "aur-update-checker")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("请求失败: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("请求失败，状态码: %d", resp.StatusCode)
+	}
+
+	reader, err := common.NewDecompressReader(path.Base(indexURL), resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("解压索引失败: %v", err)
+	}
+	defer reader.Close()
+
+	return parseAptPackages(reader, packageName)
+}
+
+// parseAptPackages 解析Packages索引，返回指定软件包的所有条目
+// 索引由空行分隔的条目组成，每行格式为 "字段: 值"，以空白开头的行是上一字段的续行
+func parseAptPackages(reader io.Reader, packageName string) ([]AptPackage, error) {
+	var packages []AptPackage
+	var current AptPackage
+
+	flush := func() {
+		if current.Package == packageName && current.Version != "" {
+			packages = append(packages, current)
+		}
+		current = AptPackage{}
+	}
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			flush()
+			continue
+		}
+		// 续行只出现在Description等多行字段中
+		if line[0] == ' ' || line[0] == '\t' {
+			continue
+		}
+
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case "Package":
+			current.Package = value
+		case "Version":
+			current.Version = value
+		case "Filename":
+			current.Filename = value
+		case "Size":
+			current.Size, _ = strconv.ParseInt(value, 10, 64)
+		case "SHA256":
+			current.SHA256 = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("解析索引失败: %v", err)
+	}
+	flush()
+
+	return packages, nil
+}
+
+// selectPackage 按Debian版本比较规则选择最新的条目，返回条目和去掉epoch及Debian修订号的上游版本
+// 不检查测试版本时跳过测试版本
+func (c *AptChecker) selectPackage(packages []AptPackage, checkTestVersion int) (*AptPackage, string) {
+	comparator := versionProcessor.NewVersionComparator()
+
+	var latest *AptPackage
+	for i := range packages {
+		if checkTestVersion != 1 && !comparator.IsStableVersion(debianUpstreamVersion(packages[i].Version)) {
+			continue
+		}
+		if latest == nil || compareDebianVersions(packages[i].Version, latest.Version) > 0 {
+			latest = &packages[i]
+		}
+	}
+	if latest == nil {
+		return nil, ""
+	}
+	return latest, debianUpstreamVersion(latest.Version)
+}
+
+// debianUpstreamVersion 从Debian版本号 [epoch:]upstream_version[-debian_revision] 中提取上游版本
+func debianUpstreamVersion(version string) string {
+	if i := strings.Index(version, ":"); i >= 0 {
+		version = version[i+1:]
+	}
+	if i := strings.LastIndex(version, "-"); i >= 0 {
+		version = version[:i]
+	}
+	return version
+}
+
+// compareDebianVersions 按dpkg的规则比较两个Debian版本号
+// 依次比较epoch、上游版本和Debian修订号
+func compareDebianVersions(a, b string) int {
+	epochA, upstreamA, revisionA := splitDebianVersion(a)
+	epochB, upstreamB, revisionB := splitDebianVersion(b)
+
+	if epochA != epochB {
+		if epochA > epochB {
+			return 1
+		}
+		return -1
+	}
+	if result := compareDebianVersionPart(upstreamA, upstreamB); result != 0 {
+		return result
+	}
+	return compareDebianVersionPart(revisionA, revisionB)
+}
+
+// splitDebianVersion 将Debian版本号拆分为epoch、上游版本和Debian修订号
+func splitDebianVersion(version string) (int, string, string) {
+	epoch := 0
+	if i := strings.Index(version, ":"); i >= 0 {
+		epoch, _ = strconv.Atoi(version[:i])
+		version = version[i+1:]
+	}
+	revision := ""
+	if i := strings.LastIndex(version, "-"); i >= 0 {
+		revision = version[i+1:]
+		version = version[:i]
+	}
+	return epoch, version, revision
+}
+
+// compareDebianVersionPart 按dpkg的verrevcmp比较版本号的一部分
+// 非数字部分逐字符比较，~ 排在所有字符（包括结尾）之前，字母排在非字母之前；数字部分按数值比较
+func compareDebianVersionPart(a, b string) int {
+	isDigit := func(s string) bool { return s != "" && s[0] >= '0' && s[0] <= '9' }
+	order := func(s string) int {
+		if s == "" {
+			return 0
+		}
+		ch := s[0]
+		switch {
+		case ch >= '0' && ch <= '9':
+			return 0
+		case (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z'):
+			return int(ch)
+		case ch == '~':
+			return -1
+		default:
+			return int(ch) + 256
+		}
+	}
+	sign := func(n int) int {
+		switch {
+		case n > 0:
+			return 1
+		case n < 0:
+			return -1
+		}
+		return 0
+	}
+
+	for a != "" || b != "" {
+		for (a != "" && !isDigit(a)) || (b != "" && !isDigit(b)) {
+			if diff := order(a) - order(b); diff != 0 {
+				return sign(diff)
+			}
+			if a != "" {
+				a = a[1:]
+			}
+			if b != "" {
+				b = b[1:]
+			}
+		}
+
+		a = strings.TrimLeft(a, "0")
+		b = strings.TrimLeft(b, "0")
+
+		firstDiff := 0
+		for isDigit(a) && isDigit(b) {
+			if firstDiff == 0 {
+				firstDiff = int(a[0]) - int(b[0])
+			}
+			a = a[1:]
+			b = b[1:]
+		}
+		if isDigit(a) {
+			return 1
+		}
+		if isDigit(b) {
+			return -1
+		}
+		if firstDiff != 0 {
+			return sign(firstDiff)
+		}
+	}
+	return 0
+}
@@ -0,0 +1,194 @@
+package checkers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAptCheckerSupports(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://repo.example.com/debian/dists/stable/main/binary-amd64/", true},
+		{"https://repo.example.com/dists/stable", true},
+		{"https://repo.example.com/debian/dists/stable/main/binary-amd64/Packages", true},
+		{"https://repo.example.com/debian/dists/stable/main/binary-amd64/Packages.gz", true},
+		{"https://repo.example.com/pool/Packages.xz", true},
+		{"https://example.com/download/deb", false},
+		{"https://example.com/app/deb/", false},
+		{"https://example.com/packages.html", false},
+	}
+	checker := NewAptChecker()
+	for _, tt := range tests {
+		if got := checker.Supports(tt.url); got != tt.want {
+			t.Errorf("Supports(%q) = %v，期望 %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestCompareDebianVersions(t *testing.T) {
+	tests := []struct {
+		a    string
+		b    string
+		want int
+	}{
+		// epoch优先于上游版本
+		{"1:1.0", "2.0", 1},
+		{"1:1.0", "2:0.1", -1},
+		{"0:1.0", "1.0", 0},
+		// ~ 排在结尾之前
+		{"1.0~rc1", "1.0", -1},
+		{"1.0~rc1", "1.0~rc2", -1},
+		{"1.0~~", "1.0~", -1},
+		{"1.0~beta", "1.0~alpha", 1},
+		// Debian修订号
+		{"1.0-1", "1.0-2", -1},
+		{"1.0-1", "1.0-1+deb12u1", -1},
+		{"1.0-1+deb11u2", "1.0-1+deb12u1", -1},
+		{"1.0-1ubuntu1", "1.0-1", 1},
+		{"1.0", "1.0-0", 0},
+		// 上游版本中的 - 以最后一个为修订号分隔符
+		{"1.0-beta-2", "1.0-beta-10", -1},
+		// 数字部分按数值比较，忽略前导0
+		{"1.10", "1.9", 1},
+		{"1.010", "1.10", 0},
+		{"2.0", "10.0", -1},
+		// 字母排在非字母之前
+		{"1.0a", "1.0+", -1},
+		{"1.0+dfsg", "1.0", 1},
+		{"1.0.1", "1.0+dfsg", 1},
+	}
+	for _, tt := range tests {
+		if got := compareDebianVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareDebianVersions(%q, %q) = %d，期望 %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := compareDebianVersions(tt.b, tt.a); got != -tt.want {
+			t.Errorf("compareDebianVersions(%q, %q) = %d，期望 %d", tt.b, tt.a, got, -tt.want)
+		}
+	}
+}
+
+func TestCompareDebianVersionPart(t *testing.T) {
+	tests := []struct {
+		a    string
+		b    string
+		want int
+	}{
+		{"", "", 0},
+		{"~", "", -1},
+		{"~~a", "~", -1},
+		{"a", "", 1},
+		{"a", "B", 1},
+		{"a", ".", -1},
+		{"1", "", 1},
+		{"0", "", 0},
+		{"deb12u1", "deb12u10", -1},
+		{"1ubuntu0.22.04.1", "1ubuntu0.20.04.3", 1},
+	}
+	for _, tt := range tests {
+		if got := compareDebianVersionPart(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareDebianVersionPart(%q, %q) = %d，期望 %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := compareDebianVersionPart(tt.b, tt.a); got != -tt.want {
+			t.Errorf("compareDebianVersionPart(%q, %q) = %d，期望 %d", tt.b, tt.a, got, -tt.want)
+		}
+	}
+}
+
+// aptTestPackages 包含多个软件包和多个版本的Packages索引
+const aptTestPackages = `Package: code
+Version: 1.90.0-1717531825
+Architecture: amd64
+Filename: pool/main/c/code/code_1.90.0-1717531825_amd64.deb
+Size: 98765432
+SHA256: 1111111111111111111111111111111111111111111111111111111111111111
+Description: Code editing. Redefined.
+ Visual Studio Code is a new choice of tool that combines the simplicity
+ of a code editor with what developers need.
+ .
+ Version: 9.9.9
+
+Package: code-insiders
+Version: 1.91.0-1718000000
+Filename: pool/main/c/code-insiders/code-insiders_1.91.0-1718000000_amd64.deb
+
+Package: code
+Version: 1.100.2-1747260578
+Architecture: amd64
+Filename: pool/main/c/code/code_1.100.2-1747260578_amd64.deb
+Size: 104857600
+SHA256: 2222222222222222222222222222222222222222222222222222222222222222
+
+Package: code
+Version: 1:1.0.0-1
+Filename: pool/main/c/code/code_1.0.0-1_amd64.deb
+
+Package: code
+Version: 1:1.1.0~rc1-1
+Filename: pool/main/c/code/code_1.1.0~rc1-1_amd64.deb
+
+Package: code
+Filename: pool/main/c/code/code_broken_amd64.deb
+`
+
+func TestParseAptPackages(t *testing.T) {
+	packages, err := parseAptPackages(strings.NewReader(aptTestPackages), "code")
+	if err != nil {
+		t.Fatalf("parseAptPackages 返回错误: %v", err)
+	}
+	want := []AptPackage{
+		{Package: "code", Version: "1.90.0-1717531825", Filename: "pool/main/c/code/code_1.90.0-1717531825_amd64.deb", Size: 98765432, SHA256: strings.Repeat("1", 64)},
+		{Package: "code", Version: "1.100.2-1747260578", Filename: "pool/main/c/code/code_1.100.2-1747260578_amd64.deb", Size: 104857600, SHA256: strings.Repeat("2", 64)},
+		{Package: "code", Version: "1:1.0.0-1", Filename: "pool/main/c/code/code_1.0.0-1_amd64.deb"},
+		{Package: "code", Version: "1:1.1.0~rc1-1", Filename: "pool/main/c/code/code_1.1.0~rc1-1_amd64.deb"},
+	}
+	if !reflect.DeepEqual(packages, want) {
+		t.Errorf("parseAptPackages = %+v，期望 %+v", packages, want)
+	}
+
+	// 没有结尾空行和使用CRLF换行的索引
+	packages, err = parseAptPackages(strings.NewReader("Package: code-insiders\r\nVersion: 1.91.0-1718000000\r\n\r\nPackage: code\r\nVersion: 1.0-1"), "code-insiders")
+	if err != nil || len(packages) != 1 || packages[0].Version != "1.91.0-1718000000" {
+		t.Errorf("parseAptPackages(code-insiders) = %+v, %v", packages, err)
+	}
+
+	if packages, err := parseAptPackages(strings.NewReader(aptTestPackages), "missing"); err != nil || len(packages) != 0 {
+		t.Errorf("parseAptPackages(missing) = %+v, %v，期望没有条目", packages, err)
+	}
+}
+
+func TestAptCheckerSelectPackage(t *testing.T) {
+	packages, err := parseAptPackages(strings.NewReader(aptTestPackages), "code")
+	if err != nil {
+		t.Fatalf("parseAptPackages 返回错误: %v", err)
+	}
+
+	checker := NewAptChecker()
+	tests := []struct {
+		name             string
+		packages         []AptPackage
+		checkTestVersion int
+		wantVersion      string
+		wantUpstream     string
+	}{
+		{"epoch优先", packages, 0, "1:1.0.0-1", "1.0.0"},
+		{"检查测试版本", packages, 1, "1:1.1.0~rc1-1", "1.1.0~rc1"},
+		{"没有epoch时按上游版本比较", packages[:2], 0, "1.100.2-1747260578", "1.100.2"},
+		{"只有测试版本", packages[3:], 0, "", ""},
+		{"没有条目", nil, 0, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			latest, upstream := checker.selectPackage(tt.packages, tt.checkTestVersion)
+			gotVersion := ""
+			if latest != nil {
+				gotVersion = latest.Version
+			}
+			if gotVersion != tt.wantVersion || upstream != tt.wantUpstream {
+				t.Errorf("selectPackage = %q (%q)，期望 %q (%q)", gotVersion, upstream, tt.wantVersion, tt.wantUpstream)
+			}
+		})
+	}
+}
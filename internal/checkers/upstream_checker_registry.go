@@ -106,6 +106,57 @@ func init() {
 	RegisterChecker("gitlab", func() common.UpstreamChecker { return NewGitLabChecker() })
 	logger.GlobalLogger.Debug("已注册检查器: gitlab")
 
+	RegisterChecker("gitea", func() common.UpstreamChecker { return NewGiteaChecker() })
+	logger.GlobalLogger.Debug("已注册检查器: gitea")
+
+	RegisterChecker("gitrefs", func() common.UpstreamChecker { return NewGitRefsChecker() })
+	logger.GlobalLogger.Debug("已注册检查器: gitrefs")
+
+	RegisterChecker("apt", func() common.UpstreamChecker { return NewAptChecker() })
+	logger.GlobalLogger.Debug("已注册检查器: apt")
+
+	RegisterChecker("rpm", func() common.UpstreamChecker { return NewRpmChecker() })
+	logger.GlobalLogger.Debug("已注册检查器: rpm")
+
+	RegisterChecker("maven", func() common.UpstreamChecker { return NewMavenChecker() })
+	logger.GlobalLogger.Debug("已注册检查器: maven")
+
+	RegisterChecker("gomod", func() common.UpstreamChecker { return NewGoModChecker() })
+	logger.GlobalLogger.Debug("已注册检查器: gomod")
+
+	RegisterChecker("crates", func() common.UpstreamChecker { return NewCratesChecker() })
+	logger.GlobalLogger.Debug("已注册检查器: crates")
+
+	RegisterChecker("rubygems", func() common.UpstreamChecker { return NewRubyGemsChecker() })
+	logger.GlobalLogger.Debug("已注册检查器: rubygems")
+
+	RegisterChecker("packagist", func() common.UpstreamChecker { return NewPackagistChecker() })
+	logger.GlobalLogger.Debug("已注册检查器: packagist")
+
+	RegisterChecker("hackage", func() common.UpstreamChecker { return NewHackageChecker() })
+	logger.GlobalLogger.Debug("已注册检查器: hackage")
+
+	RegisterChecker("metacpan", func() common.UpstreamChecker { return NewMetaCPANChecker() })
+	logger.GlobalLogger.Debug("已注册检查器: metacpan")
+
+	RegisterChecker("vsx", func() common.UpstreamChecker { return NewVSXChecker() })
+	logger.GlobalLogger.Debug("已注册检查器: vsx")
+
+	RegisterChecker("sourceforge", func() common.UpstreamChecker { return NewSourceForgeChecker() })
+	logger.GlobalLogger.Debug("已注册检查器: sourceforge")
+
+	RegisterChecker("oci", func() common.UpstreamChecker { return NewOCIChecker() })
+	logger.GlobalLogger.Debug("已注册检查器: oci")
+
+	RegisterChecker("electron", func() common.UpstreamChecker { return NewElectronChecker() })
+	logger.GlobalLogger.Debug("已注册检查器: electron")
+
+	RegisterChecker("feed", func() common.UpstreamChecker { return NewFeedChecker() })
+	logger.GlobalLogger.Debug("已注册检查器: feed")
+
+	RegisterChecker("dirlist", func() common.UpstreamChecker { return NewDirListChecker() })
+	logger.GlobalLogger.Debug("已注册检查器: dirlist")
+
 	RegisterChecker("json", func() common.UpstreamChecker { return NewJsonChecker() })
 	logger.GlobalLogger.Debug("已注册检查器: json")
 
@@ -124,6 +175,9 @@ func init() {
 	RegisterChecker("playwright", func() common.UpstreamChecker { return NewPlaywrightChecker() })
 	logger.GlobalLogger.Debug("已注册检查器: playwright")
 
+	RegisterChecker("cmd", func() common.UpstreamChecker { return NewCmdChecker() })
+	logger.GlobalLogger.Debug("已注册检查器: cmd")
+
 	logger.GlobalLogger.Info("上游检查器注册器初始化完成")
 }
 
@@ -0,0 +1,278 @@
+package checkers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	"aur-update-checker/internal/checkers/common"
+	versionProcessor "aur-update-checker/internal/checkers/version"
+	"aur-update-checker/internal/config"
+	"aur-update-checker/internal/logger"
+
+	checkerInterfaces "aur-update-checker/internal/interfaces/checkers"
+)
+
+const (
+	// defaultCmdTimeout 命令的默认超时时间
+	defaultCmdTimeout = 30 * time.Second
+	// cmdMaxOutputSize 标准输出和标准错误各自保留的最大长度
+	cmdMaxOutputSize = 1 << 20
+	// cmdMaxLoggedStderr 记录到日志中的标准错误的最大长度
+	cmdMaxLoggedStderr = 4096
+)
+
+// defaultCmdPassEnv 默认传递给命令的环境变量，其他环境变量（如API令牌）不会传递
+var defaultCmdPassEnv = []string{
+	"PATH", "HOME", "USER", "LANG", "LC_ALL", "TZ", "TMPDIR",
+	"http_proxy", "https_proxy", "no_proxy", "HTTP_PROXY", "HTTPS_PROXY", "NO_PROXY",
+	"SystemRoot", "ComSpec", "PATHEXT", "TEMP", "TMP",
+}
+
+// CmdChecker 命令检查器，运行用户配置的命令，从标准输出中获取版本号
+// 上游URL是要运行的命令，由shell执行；出于安全考虑，只有配置文件中 global.enableCmdChecker 为 true 时才会运行
+type CmdChecker struct {
+	*checkerInterfaces.BaseChecker
+	timeout time.Duration
+	// shell 运行命令的shell及其参数，命令作为最后一个参数
+	shell   []string
+	workDir string
+	passEnv []string
+	env     map[string]string
+}
+
+// NewCmdChecker 创建命令检查器
+func NewCmdChecker() *CmdChecker {
+	shell := []string{"/bin/sh", "-c"}
+	if runtime.GOOS == "windows" {
+		shell = []string{"cmd", "/C"}
+	}
+	return &CmdChecker{
+		BaseChecker: checkerInterfaces.NewBaseChecker("cmd"),
+		timeout:     defaultCmdTimeout,
+		shell:       shell,
+		passEnv:     defaultCmdPassEnv,
+	}
+}
+
+// ApplySettings 应用检查器配置，读取超时时间，以及CustomParams中的shell、工作目录和环境变量
+func (c *CmdChecker) ApplySettings(settings config.CheckerSettings) {
+	if settings.Timeout > 0 {
+		c.timeout = time.Duration(settings.Timeout) * time.Second
+	}
+	if shell := strings.Fields(common.GetStringParam(settings.CustomParams, "shell", "")); len(shell) > 0 {
+		c.shell = shell
+	}
+	c.workDir = common.GetStringParam(settings.CustomParams, "work_dir", "")
+	if passEnv := common.GetStringSliceParam(settings.CustomParams, "pass_env"); len(passEnv) > 0 {
+		c.passEnv = passEnv
+	}
+	c.env = common.GetStringMapParam(settings.CustomParams, "env")
+}
+
+// Supports 命令检查器只在软件包明确指定时使用，自动选择检查器时不使用
+func (c *CmdChecker) Supports(url string) bool {
+	return false
+}
+
+// Priority 不参与自动选择，优先级最低
+func (c *CmdChecker) Priority() int {
+	return 0
+}
+
+// Check 实现检查器接口，运行命令获取版本
+func (c *CmdChecker) Check(ctx context.Context, url, versionExtractKey string) (string, error) {
+	// 默认不检查测试版本
+	return c.CheckWithOption(ctx, url, versionExtractKey, 0)
+}
+
+// CheckWithOption 实现检查器接口，根据选项运行命令获取版本
+func (c *CmdChecker) CheckWithOption(ctx context.Context, url, versionExtractKey string, checkTestVersion int) (string, error) {
+	return c.CheckWithVersionRef(ctx, url, versionExtractKey, "", checkTestVersion)
+}
+
+// CheckWithVersionRef 实现检查器接口，根据选项和版本引用运行命令获取版本
+func (c *CmdChecker) CheckWithVersionRef(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (string, error) {
+	result, err := c.CheckWithResult(ctx, url, versionExtractKey, versionRef, checkTestVersion)
+	if err != nil {
+		return "", err
+	}
+	return result.Version, nil
+}
+
+// CheckWithResult 实现检查器接口，该检查器只能获取版本号，结果中不包含发布时间
+// 未提供版本提取关键字时使用标准输出的最后一个非空行；提供时作为正则表达式匹配标准输出，有捕获组时取第一个捕获组，多个匹配中选择最新的版本
+func (c *CmdChecker) CheckWithResult(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (*common.UpstreamCheckResult, error) {
+	if !config.GetConfig().Global.EnableCmdChecker {
+		logger.GlobalLogger.Errorf("[cmd] 命令检查器未启用，需要在配置文件中设置 global.enableCmdChecker 为 true")
+		return nil, fmt.Errorf("命令检查器未启用，需要在配置文件中设置 global.enableCmdChecker 为 true")
+	}
+
+	command := strings.TrimSpace(url)
+	if command == "" {
+		logger.GlobalLogger.Errorf("[cmd] 命令检查器需要在上游URL中填写要运行的命令")
+		return nil, fmt.Errorf("命令检查器需要在上游URL中填写要运行的命令")
+	}
+
+	stdout, err := c.run(ctx, command)
+	if err != nil {
+		logger.GlobalLogger.Errorf("[cmd] %v", err)
+		return nil, err
+	}
+
+	version, err := c.extractVersion(stdout, versionExtractKey, checkTestVersion)
+	if err != nil {
+		logger.GlobalLogger.Errorf("[cmd] %v", err)
+		return nil, err
+	}
+	logger.GlobalLogger.Debugf("[cmd] 从命令输出中提取到版本: %s", version)
+
+	// 规范化版本号，移除平台特定信息
+	normalizedVersion := c.BaseChecker.NormalizeVersionWithOption(version, checkTestVersion)
+	return common.NewVersionResult(normalizedVersion, ""), nil
+}
+
+// run 在受限的环境中运行命令，返回标准输出；标准错误记录到日志中
+func (c *CmdChecker) run(ctx context.Context, command string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	workDir := c.workDir
+	if workDir == "" {
+		// 未配置工作目录时在空的临时目录中运行，运行结束后删除
+		tempDir, err := os.MkdirTemp("", "aur-update-checker-cmd-")
+		if err != nil {
+			return "", fmt.Errorf("创建临时工作目录失败: %v", err)
+		}
+		defer os.RemoveAll(tempDir)
+		workDir = tempDir
+	}
+
+	args := append(append([]string{}, c.shell[1:]...), command)
+	cmd := exec.CommandContext(ctx, c.shell[0], args...)
+	cmd.Dir = workDir
+	cmd.Env = c.environ()
+	// 命令启动的子进程仍占用输出管道时，超时后不再等待
+	cmd.WaitDelay = time.Second
+
+	stdout := &limitedBuffer{limit: cmdMaxOutputSize}
+	stderr := &limitedBuffer{limit: cmdMaxOutputSize}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	logger.GlobalLogger.Debugf("[cmd] 运行命令: %s，工作目录: %s，超时时间: %v", command, workDir, c.timeout)
+	start := time.Now()
+	err := cmd.Run()
+	logger.GlobalLogger.Debugf("[cmd] 命令运行结束，耗时: %v", time.Since(start))
+
+	stderrText := strings.TrimSpace(stderr.String())
+	if stderrText != "" {
+		logger.GlobalLogger.Infof("[cmd] 命令标准错误输出: %s", truncateCmdOutput(stderrText, cmdMaxLoggedStderr))
+	}
+
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return "", fmt.Errorf("命令运行超时（%v）", c.timeout)
+		}
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && stderrText != "" {
+			return "", fmt.Errorf("命令运行失败，退出码 %d: %s", exitErr.ExitCode(), truncateCmdOutput(lastCmdOutputLine(stderrText), 200))
+		}
+		return "", fmt.Errorf("命令运行失败: %v", err)
+	}
+	return stdout.String(), nil
+}
+
+// environ 返回传递给命令的环境变量：允许传递的当前环境变量，以及配置中的环境变量
+func (c *CmdChecker) environ() []string {
+	var env []string
+	for _, name := range c.passEnv {
+		if value, ok := os.LookupEnv(name); ok {
+			env = append(env, name+"="+value)
+		}
+	}
+	for name, value := range c.env {
+		env = append(env, name+"="+value)
+	}
+	return env
+}
+
+// extractVersion 从命令的标准输出中提取版本号
+func (c *CmdChecker) extractVersion(stdout, versionExtractKey string, checkTestVersion int) (string, error) {
+	if strings.TrimSpace(stdout) == "" {
+		return "", fmt.Errorf("命令没有输出")
+	}
+
+	if versionExtractKey == "" {
+		return lastCmdOutputLine(stdout), nil
+	}
+
+	pattern, err := regexp.Compile(versionExtractKey)
+	if err != nil {
+		return "", fmt.Errorf("版本提取关键字不是有效的正则表达式: %v", err)
+	}
+
+	var versions []string
+	for _, match := range pattern.FindAllStringSubmatch(stdout, -1) {
+		version := match[0]
+		if len(match) > 1 {
+			version = match[1]
+		}
+		if version = strings.TrimSpace(version); version != "" {
+			versions = append(versions, version)
+		}
+	}
+	if len(versions) == 0 {
+		return "", fmt.Errorf("正则表达式 %s 没有匹配到命令输出", versionExtractKey)
+	}
+
+	comparator := versionProcessor.NewVersionComparator()
+	latest := ""
+	for _, version := range versions {
+		if checkTestVersion != 1 && !comparator.IsStableVersion(version) {
+			continue
+		}
+		if latest == "" || comparator.CompareVersions(version, latest) > 0 {
+			latest = version
+		}
+	}
+	if latest == "" {
+		return "", fmt.Errorf("命令输出中只有测试版本: %v", versions)
+	}
+	return latest, nil
+}
+
+// lastCmdOutputLine 返回输出的最后一个非空行
+func lastCmdOutputLine(output string) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+// truncateCmdOutput 截断过长的输出
+func truncateCmdOutput(output string, limit int) string {
+	if len(output) <= limit {
+		return output
+	}
+	return output[:limit] + "..."
+}
+
+// limitedBuffer 只保留前limit个字节的输出，超出部分丢弃，避免命令输出过多占用内存
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+// Write 实现 io.Writer，总是返回完整的长度，避免命令因管道写入失败而退出
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if remaining := b.limit - b.Len(); remaining > 0 {
+		b.Buffer.Write(p[:min(len(p), remaining)])
+	}
+	return len(p), nil
+}
@@ -0,0 +1,213 @@
+package checkers
+
+import (
+	"context"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"aur-update-checker/internal/config"
+)
+
+// enableCmdChecker 在测试期间启用命令检查器，测试结束后恢复原来的配置
+func enableCmdChecker(t *testing.T, enabled bool) {
+	t.Helper()
+	global := &config.GetConfig().Global
+	previous := global.EnableCmdChecker
+	global.EnableCmdChecker = enabled
+	t.Cleanup(func() { global.EnableCmdChecker = previous })
+}
+
+// skipCmdCheckerOnWindows 测试使用 /bin/sh 的命令
+func skipCmdCheckerOnWindows(t *testing.T) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("测试命令需要 /bin/sh")
+	}
+}
+
+func TestCmdCheckerDisabledByDefault(t *testing.T) {
+	enableCmdChecker(t, false)
+
+	checker := NewCmdChecker()
+	if _, err := checker.CheckWithResult(context.Background(), "echo 1.2.3", "", "", 0); err == nil || !strings.Contains(err.Error(), "未启用") {
+		t.Fatalf("命令检查器未启用时 CheckWithResult 错误 = %v，期望返回未启用的错误", err)
+	}
+}
+
+func TestCmdCheckerCheckWithResult(t *testing.T) {
+	skipCmdCheckerOnWindows(t)
+	enableCmdChecker(t, true)
+
+	tests := []struct {
+		name             string
+		command          string
+		key              string
+		checkTestVersion int
+		want             string
+	}{
+		{"最后一个非空行", "echo building; echo 1.2.3; echo", "", 0, "1.2.3"},
+		{"正则表达式捕获组", `printf 'tool v2.0.1\ntool v2.10.0\n'`, `v(\d+\.\d+\.\d+)`, 0, "2.10.0"},
+		{"空的上游URL", "   ", "", 0, ""},
+	}
+	checker := NewCmdChecker()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := checker.CheckWithResult(context.Background(), tt.command, tt.key, "", tt.checkTestVersion)
+			if tt.want == "" {
+				if err == nil {
+					t.Fatalf("CheckWithResult(%q) = %+v，期望返回错误", tt.command, result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CheckWithResult(%q) 返回错误: %v", tt.command, err)
+			}
+			if result.Version != tt.want {
+				t.Errorf("CheckWithResult(%q) = %q，期望 %q", tt.command, result.Version, tt.want)
+			}
+		})
+	}
+}
+
+func TestCmdCheckerTimeout(t *testing.T) {
+	skipCmdCheckerOnWindows(t)
+
+	checker := NewCmdChecker()
+	checker.timeout = 200 * time.Millisecond
+	start := time.Now()
+	_, err := checker.run(context.Background(), "sleep 10")
+	if err == nil || !strings.Contains(err.Error(), "超时") {
+		t.Fatalf("run 错误 = %v，期望返回超时错误", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("命令超时后 %v 才返回", elapsed)
+	}
+}
+
+func TestCmdCheckerEnviron(t *testing.T) {
+	skipCmdCheckerOnWindows(t)
+	t.Setenv("GITHUB_TOKEN", "secret-token")
+	t.Setenv("AUR_CHECKER_TEST_SECRET", "secret-value")
+	t.Setenv("LANG", "C.UTF-8")
+
+	checker := NewCmdChecker()
+	checker.env = map[string]string{"TOOL_CHANNEL": "stable"}
+
+	env := checker.environ()
+	allowed := map[string]bool{"TOOL_CHANNEL": true}
+	for _, name := range checker.passEnv {
+		allowed[name] = true
+	}
+	for _, entry := range env {
+		name := strings.SplitN(entry, "=", 2)[0]
+		if !allowed[name] {
+			t.Errorf("environ() 包含不允许传递的环境变量: %s", entry)
+		}
+	}
+	if !slices.Contains(env, "LANG=C.UTF-8") || !slices.Contains(env, "TOOL_CHANNEL=stable") {
+		t.Errorf("environ() = %q，期望包含 LANG 和配置中的 TOOL_CHANNEL", env)
+	}
+
+	// 命令实际看到的环境变量
+	output, err := checker.run(context.Background(), "env")
+	if err != nil {
+		t.Fatalf("run(env) 返回错误: %v", err)
+	}
+	for _, secret := range []string{"GITHUB_TOKEN", "secret-token", "AUR_CHECKER_TEST_SECRET"} {
+		if strings.Contains(output, secret) {
+			t.Errorf("命令的环境变量中包含 %s: %s", secret, output)
+		}
+	}
+
+	// 配置了 pass_env 时只传递其中的环境变量
+	checker.ApplySettings(config.CheckerSettings{CustomParams: map[string]interface{}{
+		"pass_env": []interface{}{"AUR_CHECKER_TEST_SECRET"},
+	}})
+	env = checker.environ()
+	if len(env) != 1 || env[0] != "AUR_CHECKER_TEST_SECRET=secret-value" {
+		t.Errorf("配置 pass_env 后 environ() = %q，期望只有 AUR_CHECKER_TEST_SECRET", env)
+	}
+}
+
+func TestCmdCheckerStderr(t *testing.T) {
+	skipCmdCheckerOnWindows(t)
+
+	checker := NewCmdChecker()
+	_, err := checker.run(context.Background(), "echo 'first error' >&2; echo 'fatal: repository not found' >&2; exit 3")
+	if err == nil {
+		t.Fatalf("命令失败时 run 期望返回错误")
+	}
+	if msg := err.Error(); !strings.Contains(msg, "退出码 3") || !strings.Contains(msg, "fatal: repository not found") || strings.Contains(msg, "first error") {
+		t.Errorf("run 错误 = %q，期望包含退出码和标准错误的最后一行", msg)
+	}
+
+	// 标准错误的最后一行过长时截断
+	_, err = checker.run(context.Background(), "head -c 1000 /dev/zero | tr '\\0' x >&2; exit 1")
+	if err == nil {
+		t.Fatalf("命令失败时 run 期望返回错误")
+	}
+	if msg := err.Error(); !strings.HasSuffix(msg, strings.Repeat("x", 200)+"...") || strings.Contains(msg, strings.Repeat("x", 201)) {
+		t.Errorf("run 错误 = %q，期望截断为200个字符", msg)
+	}
+
+	// 标准错误不影响标准输出
+	output, err := checker.run(context.Background(), "echo warning >&2; echo 1.0.0")
+	if err != nil || strings.TrimSpace(output) != "1.0.0" {
+		t.Errorf("run = %q, %v，期望只返回标准输出", output, err)
+	}
+}
+
+func TestCmdOutputLimits(t *testing.T) {
+	if got := truncateCmdOutput("abcdef", 3); got != "abc..." {
+		t.Errorf("truncateCmdOutput = %q，期望 %q", got, "abc...")
+	}
+	if got := truncateCmdOutput("abc", 3); got != "abc" {
+		t.Errorf("truncateCmdOutput = %q，期望 %q", got, "abc")
+	}
+
+	buffer := &limitedBuffer{limit: 4}
+	for _, chunk := range []string{"ab", "cdef", "gh"} {
+		if n, err := buffer.Write([]byte(chunk)); n != len(chunk) || err != nil {
+			t.Errorf("limitedBuffer.Write(%q) = %d, %v，期望 %d, nil", chunk, n, err, len(chunk))
+		}
+	}
+	if buffer.String() != "abcd" {
+		t.Errorf("limitedBuffer = %q，期望 %q", buffer.String(), "abcd")
+	}
+}
+
+func TestCmdCheckerExtractVersion(t *testing.T) {
+	tests := []struct {
+		name             string
+		stdout           string
+		key              string
+		checkTestVersion int
+		want             string
+		wantErr          bool
+	}{
+		{"最后一个非空行", "Fetching...\n  1.4.2  \n\n", "", 0, "1.4.2", false},
+		{"整个匹配", "1.0 1.2 1.10", `\d+\.\d+`, 0, "1.10", false},
+		{"第一个捕获组", "release-1.9.0\nrelease-1.10.0\n", `release-(\S+)`, 0, "1.10.0", false},
+		{"跳过测试版本", "v2.0.0-rc1\nv1.9.9\n", `v(\S+)`, 0, "1.9.9", false},
+		{"检查测试版本", "v2.0.0-rc1\nv1.9.9\n", `v(\S+)`, 1, "2.0.0-rc1", false},
+		{"只有测试版本", "v2.0.0-beta.1\n", `v(\S+)`, 0, "", true},
+		{"没有匹配", "no versions here", `v(\d+)`, 0, "", true},
+		{"无效的正则表达式", "1.0", `(`, 0, "", true},
+		{"没有输出", " \n", "", 0, "", true},
+	}
+	checker := NewCmdChecker()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := checker.extractVersion(tt.stdout, tt.key, tt.checkTestVersion)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("extractVersion 错误 = %v，期望返回错误: %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("extractVersion = %q，期望 %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package checkers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+
+	"github.com/Masterminds/semver"
+
+	"aur-update-checker/internal/checkers/common"
+	"aur-update-checker/internal/logger"
+
+	checkerInterfaces "aur-update-checker/internal/interfaces/checkers"
+)
+
+const (
+	// cratesAPIURL crates.io的crate信息API
+	cratesAPIURL = "https://crates.io/api/v1/crates/%s"
+	// cratesDownloadURL crate源码包的下载地址
+	cratesDownloadURL = "https://static.crates.io/crates/%s/%s-%s.crate"
+	// cratesUserAgent crates.io要求API请求携带能联系到调用方的User-Agent，否则会拒绝请求
+	cratesUserAgent = "aur-update-checker (https://github.com/zxp19821005/aur-update-checker)"
+)
+
+// cratesNamePattern 匹配crates.io的crate页面地址
+var cratesNamePattern = regexp.MustCompile(`crates\.io/(?:api/v1/)?crates/([A-Za-z0-9_-]+)`)
+
+// CratesResponse crates.io的crate信息API响应
+type CratesResponse struct {
+	Crate struct {
+		Name             string `json:"name"`
+		MaxVersion       string `json:"max_version"`
+		MaxStableVersion string `json:"max_stable_version"`
+	} `json:"crate"`
+	Versions []CratesVersion `json:"versions"`
+}
+
+// CratesVersion crate的版本信息
+type CratesVersion struct {
+	Num       string `json:"num"`
+	Yanked    bool   `json:"yanked"`
+	CreatedAt string `json:"created_at"`
+	Checksum  string `json:"checksum"`
+	CrateSize int64  `json:"crate_size"`
+}
+
+// CratesChecker crates.io检查器
+type CratesChecker struct {
+	*checkerInterfaces.BaseChecker
+	client *http.Client
+}
+
+// NewCratesChecker 创建crates.io检查器
+func NewCratesChecker() *CratesChecker {
+	return &CratesChecker{
+		BaseChecker: checkerInterfaces.NewBaseChecker("crates"),
+		client:      &http.Client{},
+	}
+}
+
+// Supports 检查URL是否是crates.io的crate地址
+func (c *CratesChecker) Supports(url string) bool {
+	return cratesNamePattern.MatchString(url)
+}
+
+// Priority 专用于crates.io，优先级高于通用检查器
+func (c *CratesChecker) Priority() int {
+	return 80
+}
+
+// Check 实现检查器接口，获取crate的最新版本
+func (c *CratesChecker) Check(ctx context.Context, url, versionExtractKey string) (string, error) {
+	// 默认不检查测试版本
+	return c.CheckWithOption(ctx, url, versionExtractKey, 0)
+}
+
+// CheckWithOption 实现检查器接口，根据选项获取crate的最新版本
+func (c *CratesChecker) CheckWithOption(ctx context.Context, url, versionExtractKey string, checkTestVersion int) (string, error) {
+	return c.CheckWithVersionRef(ctx, url, versionExtractKey, "", checkTestVersion)
+}
+
+// CheckWithVersionRef 带选项和版本引用地检查上游版本
+func (c *CratesChecker) CheckWithVersionRef(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (string, error) {
+	result, err := c.CheckWithResult(ctx, url, versionExtractKey, versionRef, checkTestVersion)
+	if err != nil {
+		return "", err
+	}
+	return result.Version, nil
+}
+
+// CheckWithResult 实现检查器接口，返回crate的最新版本、发布时间以及.crate源码包的下载地址和SHA256
+// 跳过已撤回（yanked）的版本；不检查测试版本时返回max_stable_version，否则返回包括预发布版本在内的最大版本
+func (c *CratesChecker) CheckWithResult(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (*common.UpstreamCheckResult, error) {
+	name, err := c.crateName(url, versionExtractKey)
+	if err != nil {
+		logger.GlobalLogger.Errorf("[crates] %v", err)
+		return nil, err
+	}
+	logger.GlobalLogger.Debugf("[crates] 开始检查crate - 名称: %s, 检查测试版本: %d", name, checkTestVersion)
+
+	info, err := c.fetchCrate(ctx, name)
+	if err != nil {
+		logger.GlobalLogger.Errorf("[crates] 获取crate信息失败: %v", err)
+		return nil, fmt.Errorf("获取crate信息失败: %v", err)
+	}
+
+	selected := c.selectVersion(info, checkTestVersion)
+	if selected == nil {
+		logger.GlobalLogger.Errorf("[crates] crate %s 没有可用的版本", name)
+		return nil, fmt.Errorf("crate %s 没有可用的版本", name)
+	}
+
+	isPrerelease := false
+	if parsed, err := semver.NewVersion(selected.Num); err == nil {
+		isPrerelease = parsed.Prerelease() != ""
+	}
+
+	result := &common.UpstreamCheckResult{
+		Version:      c.BaseChecker.NormalizeVersionWithOption(selected.Num, checkTestVersion),
+		TagName:      selected.Num,
+		IsPrerelease: isPrerelease,
+		ReleaseDate:  common.ParseReleaseDate(selected.CreatedAt),
+		SourceURL:    fmt.Sprintf("https://crates.io/crates/%s/%s", name, selected.Num),
+		Assets: []common.ReleaseAsset{{
+			Name:        fmt.Sprintf("%s-%s.crate", name, selected.Num),
+			DownloadURL: fmt.Sprintf(cratesDownloadURL, name, name, selected.Num),
+			Size:        selected.CrateSize,
+			SHA256:      selected.Checksum,
+			Default:     true,
+		}},
+	}
+
+	logger.GlobalLogger.Infof("[crates] crate %s 的最新版本: %s", name, result.Version)
+	return result, nil
+}
+
+// crateName 从URL中提取crate名称，URL不是crates.io地址时使用versionExtractKey
+func (c *CratesChecker) crateName(url, versionExtractKey string) (string, error) {
+	if matches := cratesNamePattern.FindStringSubmatch(url); len(matches) > 1 {
+		return matches[1], nil
+	}
+	if versionExtractKey != "" {
+		return versionExtractKey, nil
+	}
+	return "", fmt.Errorf("无法从URL中提取crate名称: %s", url)
+}
+
+// fetchCrate 获取crate信息和全部版本
+func (c *CratesChecker) fetchCrate(ctx context.Context, name string) (*CratesResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf(cratesAPIURL, url.PathEscape(name)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %v", err)
+	}
+	req.Header.Set("User-Agent", cratesUserAgent)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("请求失败: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("crate %s 不存在", name)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("请求失败，状态码: %d", resp.StatusCode)
+	}
+
+	var info CratesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, fmt.Errorf("解析响应失败: %v", err)
+	}
+	return &info, nil
+}
+
+// selectVersion 选择未撤回的最新版本
+// 不检查测试版本时使用API给出的max_stable_version，API未给出时按语义化版本选出最大的稳定版本
+func (c *CratesChecker) selectVersion(info *CratesResponse, checkTestVersion int) *CratesVersion {
+	type candidate struct {
+		version *semver.Version
+		info    *CratesVersion
+	}
+
+	if checkTestVersion != 1 && info.Crate.MaxStableVersion != "" {
+		for i := range info.Versions {
+			if info.Versions[i].Num == info.Crate.MaxStableVersion && !info.Versions[i].Yanked {
+				return &info.Versions[i]
+			}
+		}
+	}
+
+	var candidates []candidate
+	for i := range info.Versions {
+		v := &info.Versions[i]
+		if v.Yanked {
+			continue
+		}
+		parsed, err := semver.NewVersion(v.Num)
+		if err != nil {
+			logger.GlobalLogger.Debugf("[crates] 跳过无法解析的版本: %s", v.Num)
+			continue
+		}
+		if checkTestVersion != 1 && parsed.Prerelease() != "" {
+			continue
+		}
+		candidates = append(candidates, candidate{version: parsed, info: v})
+	}
+
+	if len(candidates) == 0 {
+		return nil
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].version.GreaterThan(candidates[j].version)
+	})
+	return candidates[0].info
+}
@@ -84,6 +84,61 @@ func (c *CurlChecker) CheckWithVersionRef(ctx context.Context, url, versionExtra
 	}
 	logger.GlobalLogger.Debugf("[curl] 成功获取页面内容，长度: %d 字符", len(content))
 
+	// 2、3. 使用版本提取关键字的上下文或 css:/xpath: 选择器提取版本号
+	var versions []string
+	if common.IsSelectorKey(versionExtractKey) {
+		logger.GlobalLogger.Debugf("[curl] 使用选择器 '%s' 提取版本号", versionExtractKey)
+		if versions, err = common.ExtractSelectorVersions(content, versionExtractKey); err == nil {
+			// 选择器提取的版本包括测试版本，不检查测试版本时先过滤掉
+			versions, err = common.FilterSelectorVersions(versions, checkTestVersion)
+		}
+		if err != nil {
+			logger.GlobalLogger.Errorf("[curl] %v", err)
+		}
+	} else {
+		versions, err = c.extractKeyVersions(content, versionExtractKey, checkTestVersion)
+	}
+	if err != nil {
+		return "", err
+	}
+	logger.GlobalLogger.Debugf("[curl] 共提取到 %d 个版本号: %v", len(versions), versions)
+
+	// 4. 调用getLatestVersion，判定最新版本
+	latestVersion := c.getLatestVersion(versions, checkTestVersion)
+	if latestVersion == "" {
+		errMsg := fmt.Errorf("无法从提取的版本中确定最新版本")
+		logger.GlobalLogger.Errorf("[curl] %v", errMsg)
+		return "", errMsg
+	}
+	logger.GlobalLogger.Debugf("[curl] 选择最新版本: %s", latestVersion)
+
+	// 如果提供了版本引用，使用版本引用来优化版本提取
+	if versionRef != "" {
+		logger.GlobalLogger.Debugf("[curl] 使用版本引用 %s 来优化版本提取", versionRef)
+		// 分析版本引用格式，确定版本号的结构
+		// 例如，如果版本引用是 a.b.c，表示期望的版本号格式是 x.y.z
+		versionFormat := c.analyzeVersionFormat(versionRef)
+		logger.GlobalLogger.Debugf("[curl] 分析得到的版本格式: %s", versionFormat)
+
+		// 根据版本格式筛选版本号
+		filteredVersions := c.filterVersionsByFormat(versions, versionFormat)
+		logger.GlobalLogger.Debugf("[curl] 根据版本格式筛选后的版本: %v", filteredVersions)
+
+		if len(filteredVersions) > 0 {
+			// 使用筛选后的版本重新选择最新版本
+			latestVersion = c.getLatestVersion(filteredVersions, checkTestVersion)
+			logger.GlobalLogger.Debugf("[curl] 使用版本引用筛选后的最新版本: %s", latestVersion)
+		}
+	}
+
+	// 规范化版本号，移除平台特定信息
+	normalizedVersion := c.BaseChecker.NormalizeVersionWithOption(latestVersion, checkTestVersion)
+	logger.GlobalLogger.Debugf("[curl] 规范化后的版本: %s", normalizedVersion)
+	return normalizedVersion, nil
+}
+
+// extractKeyVersions 查找版本提取关键字出现的位置，从前后100个字符中提取版本号
+func (c *CurlChecker) extractKeyVersions(content, versionExtractKey string, checkTestVersion int) ([]string, error) {
 	// 2. 使用版本提取关键字，提取版本提取关键字前后100个字符
 	logger.GlobalLogger.Debugf("[curl] 使用版本提取关键字 '%s' 提取上下文", versionExtractKey)
 	var contexts []string
@@ -142,7 +197,7 @@ func (c *CurlChecker) CheckWithVersionRef(ctx context.Context, url, versionExtra
 	if len(contexts) == 0 {
 		errMsg := fmt.Errorf("在内容中未找到版本提取关键字 '%s'", versionExtractKey)
 		logger.GlobalLogger.Errorf("[curl] %v", errMsg)
-		return "", errMsg
+		return nil, errMsg
 	}
 
 	// 3. 参考UpstreamVersionRef，使用extractVersionFromString从版本提取关键字前后100个字符尝试提取版本
@@ -167,42 +222,9 @@ func (c *CurlChecker) CheckWithVersionRef(ctx context.Context, url, versionExtra
 	if len(versions) == 0 {
 		errMsg := fmt.Errorf("无法从提取的上下文中解析出有效的版本号")
 		logger.GlobalLogger.Errorf("[curl] %v", errMsg)
-		return "", errMsg
-	}
-	logger.GlobalLogger.Debugf("[curl] 共提取到 %d 个版本号: %v", len(versions), versions)
-
-	// 4. 调用getLatestVersion，判定最新版本
-	latestVersion := c.getLatestVersion(versions, checkTestVersion)
-	if latestVersion == "" {
-		errMsg := fmt.Errorf("无法从提取的版本中确定最新版本")
-		logger.GlobalLogger.Errorf("[curl] %v", errMsg)
-		return "", errMsg
-	}
-	logger.GlobalLogger.Debugf("[curl] 选择最新版本: %s", latestVersion)
-
-	// 如果提供了版本引用，使用版本引用来优化版本提取
-	if versionRef != "" {
-		logger.GlobalLogger.Debugf("[curl] 使用版本引用 %s 来优化版本提取", versionRef)
-		// 分析版本引用格式，确定版本号的结构
-		// 例如，如果版本引用是 a.b.c，表示期望的版本号格式是 x.y.z
-		versionFormat := c.analyzeVersionFormat(versionRef)
-		logger.GlobalLogger.Debugf("[curl] 分析得到的版本格式: %s", versionFormat)
-
-		// 根据版本格式筛选版本号
-		filteredVersions := c.filterVersionsByFormat(versions, versionFormat)
-		logger.GlobalLogger.Debugf("[curl] 根据版本格式筛选后的版本: %v", filteredVersions)
-
-		if len(filteredVersions) > 0 {
-			// 使用筛选后的版本重新选择最新版本
-			latestVersion = c.getLatestVersion(filteredVersions, checkTestVersion)
-			logger.GlobalLogger.Debugf("[curl] 使用版本引用筛选后的最新版本: %s", latestVersion)
-		}
+		return nil, errMsg
 	}
-
-	// 规范化版本号，移除平台特定信息
-	normalizedVersion := c.BaseChecker.NormalizeVersionWithOption(latestVersion, checkTestVersion)
-	logger.GlobalLogger.Debugf("[curl] 规范化后的版本: %s", normalizedVersion)
-	return normalizedVersion, nil
+	return versions, nil
 }
 
 // extractVersionFromString 从字符串中提取版本号
@@ -497,3 +519,12 @@ func (c *CurlChecker) fetchContent(ctx context.Context, url string) (string, err
 
 	return content, nil
 }
+
+// CheckWithResult 实现检查器接口，该检查器只能获取版本号，结果中不包含发布时间
+func (c *CurlChecker) CheckWithResult(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (*common.UpstreamCheckResult, error) {
+	version, err := c.CheckWithVersionRef(ctx, url, versionExtractKey, versionRef, checkTestVersion)
+	if err != nil {
+		return nil, err
+	}
+	return common.NewVersionResult(version, url), nil
+}
@@ -0,0 +1,380 @@
+package checkers
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"aur-update-checker/internal/checkers/common"
+	versionProcessor "aur-update-checker/internal/checkers/version"
+	"aur-update-checker/internal/logger"
+
+	checkerInterfaces "aur-update-checker/internal/interfaces/checkers"
+)
+
+const (
+	// OptionRecursive 软件包检查器选项：是否进入版本号子目录查找文件，auto（默认）在当前目录没有匹配的文件时进入，true总是进入，false不进入
+	OptionRecursive = "recursive"
+
+	// dirListMaxSubdirs 进入子目录时最多检查的子目录数，按版本号从大到小检查
+	dirListMaxSubdirs = 3
+	// dirListMaxBodySize 目录列表页面的最大读取长度
+	dirListMaxBodySize = 10 << 20
+)
+
+var (
+	// dirListAnchorPattern 匹配目录列表中的链接
+	dirListAnchorPattern = regexp.MustCompile(`(?is)<a\s[^>]*?href\s*=\s*["']([^"']+)["'][^>]*>`)
+	// dirListAnchorEndPattern 匹配链接的结束标签
+	dirListAnchorEndPattern = regexp.MustCompile(`(?i)</a\s*>`)
+	// dirListTagPattern 匹配HTML标签，用于取得链接后面的修改时间和大小文本
+	dirListTagPattern = regexp.MustCompile(`(?s)<[^>]*>`)
+	// dirListRegexpChars 版本提取关键字中出现这些字符时按正则表达式处理，否则按shell通配符处理
+	dirListRegexpChars = regexp.MustCompile(`[\\^$()+|{}]`)
+	// dirListSizePattern 匹配修改时间后面以字节为单位的文件大小（nginx格式），Apache、lighttpd的 1.2M 等近似大小不使用
+	dirListSizePattern = regexp.MustCompile(`^\s*(\d+)(?:\s|$)`)
+	// dirListVersionPattern 从文件名或目录名中提取版本号，版本号前面可以是下划线，如 pkg_1.2.0.deb
+	dirListVersionPattern = regexp.MustCompile(`(?i)(?:^|[^A-Za-z0-9.])v?(\d+(?:\.\d+)+(?:[-.]?(?:alpha|beta|rc|pre|preview|dev)[.-]?\d*)?)`)
+)
+
+// dirListDateFormats 各种目录列表中修改时间的格式
+var dirListDateFormats = []struct {
+	pattern *regexp.Regexp
+	layouts []string
+}{
+	// nginx和Apache的 <pre> 格式，如 01-Jan-2024 12:00
+	{regexp.MustCompile(`\d{1,2}-[A-Za-z]{3}-\d{4} \d{2}:\d{2}(?::\d{2})?`), []string{"02-Jan-2006 15:04", "02-Jan-2006 15:04:05", "2-Jan-2006 15:04"}},
+	// Apache的表格格式，如 2024-01-01 12:00
+	{regexp.MustCompile(`\d{4}-\d{2}-\d{2} \d{2}:\d{2}(?::\d{2})?`), []string{"2006-01-02 15:04", "2006-01-02 15:04:05"}},
+	// lighttpd格式，如 2024-Jan-01 12:00:00
+	{regexp.MustCompile(`\d{4}-[A-Za-z]{3}-\d{2} \d{2}:\d{2}:\d{2}`), []string{"2006-Jan-02 15:04:05"}},
+}
+
+// DirListEntry 目录列表中的一项
+type DirListEntry struct {
+	Name    string
+	URL     string
+	IsDir   bool
+	ModTime time.Time
+	Size    int64
+}
+
+// DirListChecker Apache、nginx、lighttpd目录列表（autoindex）检查器
+type DirListChecker struct {
+	*checkerInterfaces.BaseChecker
+	client *http.Client
+}
+
+// NewDirListChecker 创建目录列表检查器
+func NewDirListChecker() *DirListChecker {
+	return &DirListChecker{
+		BaseChecker: checkerInterfaces.NewBaseChecker("dirlist"),
+		client:      &http.Client{},
+	}
+}
+
+// Supports 检查URL是否像目录地址
+func (c *DirListChecker) Supports(url string) bool {
+	return strings.HasPrefix(url, "http") && strings.HasSuffix(url, "/")
+}
+
+// Priority 目录地址也可能是普通网页，优先级低于通用HTTP检查器，需要手动选择
+func (c *DirListChecker) Priority() int {
+	return 45
+}
+
+// Check 实现检查器接口，获取目录中版本号最大的文件
+func (c *DirListChecker) Check(ctx context.Context, url, versionExtractKey string) (string, error) {
+	// 默认不检查测试版本
+	return c.CheckWithOption(ctx, url, versionExtractKey, 0)
+}
+
+// CheckWithOption 实现检查器接口，根据选项获取目录中版本号最大的文件
+func (c *DirListChecker) CheckWithOption(ctx context.Context, url, versionExtractKey string, checkTestVersion int) (string, error) {
+	return c.CheckWithVersionRef(ctx, url, versionExtractKey, "", checkTestVersion)
+}
+
+// CheckWithVersionRef 带选项和版本引用地检查上游版本
+func (c *DirListChecker) CheckWithVersionRef(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (string, error) {
+	result, err := c.CheckWithResult(ctx, url, versionExtractKey, versionRef, checkTestVersion)
+	if err != nil {
+		return "", err
+	}
+	return result.Version, nil
+}
+
+// CheckWithResult 实现检查器接口，返回目录中版本号最大的文件的版本、完整地址和修改时间
+// versionExtractKey 是文件名的shell通配符（如 app-*.tar.gz）或正则表达式（有捕获组时使用第一个捕获组作为版本号），
+// 为空时使用全部文件；文件名中没有版本号时使用所在子目录的版本号
+func (c *DirListChecker) CheckWithResult(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (*common.UpstreamCheckResult, error) {
+	if !strings.HasSuffix(url, "/") {
+		url += "/"
+	}
+	recursive := strings.ToLower(common.CheckOptionsFromContext(ctx).Get(OptionRecursive, "auto"))
+	logger.GlobalLogger.Debugf("[dirlist] 开始检查目录 - URL: %s, 文件匹配: %s, 子目录: %s", url, versionExtractKey, recursive)
+
+	matcher, err := newDirListMatcher(versionExtractKey)
+	if err != nil {
+		logger.GlobalLogger.Errorf("[dirlist] %v", err)
+		return nil, err
+	}
+
+	entries, err := c.fetchEntries(ctx, url)
+	if err != nil {
+		logger.GlobalLogger.Errorf("[dirlist] 获取目录列表失败: %v", err)
+		return nil, fmt.Errorf("获取目录列表失败: %v", err)
+	}
+
+	candidates := c.collectCandidates(entries, matcher, "", checkTestVersion)
+	if recursive == "true" || recursive == "1" || (recursive == "auto" && len(candidates) == 0) {
+		candidates = append(candidates, c.collectSubdirCandidates(ctx, entries, matcher, checkTestVersion)...)
+	}
+
+	if len(candidates) == 0 {
+		logger.GlobalLogger.Errorf("[dirlist] 在%d个目录项中未找到符合条件的文件", len(entries))
+		return nil, fmt.Errorf("在%d个目录项中未找到符合条件的文件", len(entries))
+	}
+
+	comparator := versionProcessor.NewVersionComparator()
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if result := comparator.CompareVersions(candidates[i].Version, candidates[j].Version); result != 0 {
+			return result > 0
+		}
+		return candidates[i].ReleaseDate.After(candidates[j].ReleaseDate)
+	})
+
+	result := candidates[0]
+	logger.GlobalLogger.Infof("[dirlist] 选择文件 %s，版本: %s", result.TagName, result.Version)
+	return result, nil
+}
+
+// collectSubdirCandidates 按版本号从大到小进入子目录，返回第一个有匹配文件的子目录中的候选文件
+func (c *DirListChecker) collectSubdirCandidates(ctx context.Context, entries []DirListEntry, matcher *dirListMatcher, checkTestVersion int) []*common.UpstreamCheckResult {
+	comparator := versionProcessor.NewVersionComparator()
+
+	type subdir struct {
+		entry   DirListEntry
+		version string
+	}
+	var subdirs []subdir
+	for _, entry := range entries {
+		if !entry.IsDir {
+			continue
+		}
+		version := extractFeedVersion(dirListVersionPattern, entry.Name)
+		if version == "" || (checkTestVersion != 1 && !comparator.IsStableVersion(version)) {
+			continue
+		}
+		subdirs = append(subdirs, subdir{entry: entry, version: version})
+	}
+	sort.SliceStable(subdirs, func(i, j int) bool {
+		return comparator.CompareVersions(subdirs[i].version, subdirs[j].version) > 0
+	})
+
+	for i, dir := range subdirs {
+		if i >= dirListMaxSubdirs {
+			break
+		}
+		subEntries, err := c.fetchEntries(ctx, dir.entry.URL)
+		if err != nil {
+			logger.GlobalLogger.Warnf("[dirlist] 获取子目录 %s 失败: %v", dir.entry.URL, err)
+			continue
+		}
+		if candidates := c.collectCandidates(subEntries, matcher, dir.version, checkTestVersion); len(candidates) > 0 {
+			return candidates
+		}
+	}
+	return nil
+}
+
+// collectCandidates 返回匹配的文件及其版本号，文件名中没有版本号时使用dirVersion
+func (c *DirListChecker) collectCandidates(entries []DirListEntry, matcher *dirListMatcher, dirVersion string, checkTestVersion int) []*common.UpstreamCheckResult {
+	comparator := versionProcessor.NewVersionComparator()
+
+	var candidates []*common.UpstreamCheckResult
+	for _, entry := range entries {
+		if entry.IsDir {
+			continue
+		}
+		version, ok := matcher.match(entry.Name)
+		if !ok {
+			continue
+		}
+		if version == "" {
+			version = dirVersion
+		}
+		if version == "" {
+			continue
+		}
+
+		stable := comparator.IsStableVersion(version)
+		if checkTestVersion != 1 && !stable {
+			continue
+		}
+		candidates = append(candidates, &common.UpstreamCheckResult{
+			Version:      c.BaseChecker.NormalizeVersionWithOption(version, checkTestVersion),
+			TagName:      entry.Name,
+			IsPrerelease: !stable,
+			ReleaseDate:  entry.ModTime,
+			SourceURL:    entry.URL,
+			Assets: []common.ReleaseAsset{{
+				Name:        entry.Name,
+				DownloadURL: entry.URL,
+				Size:        entry.Size,
+				Default:     true,
+			}},
+		})
+	}
+	return candidates
+}
+
+// fetchEntries 获取并解析目录列表页面
+func (c *DirListChecker) fetchEntries(ctx context.Context, url string) ([]DirListEntry, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %v", err)
+	}
+	req.Header.Set("User-Agent", "aur-update-checker")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("请求失败: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("请求失败，状态码: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, dirListMaxBodySize))
+	if err != nil {
+		return nil, fmt.Errorf("读取响应体失败: %v", err)
+	}
+
+	// 重定向后以最终地址作为相对链接的基准
+	return parseDirListing(resp.Request.URL, string(body)), nil
+}
+
+// parseDirListing 解析目录列表HTML，只保留当前目录的直接子项
+// 每个链接后面到下一个链接之前的文本中包含修改时间和大小，Apache、nginx和lighttpd都是这种结构
+func parseDirListing(base *neturl.URL, body string) []DirListEntry {
+	basePath := base.Path
+	if !strings.HasSuffix(basePath, "/") {
+		basePath = basePath[:strings.LastIndex(basePath, "/")+1]
+	}
+
+	anchors := dirListAnchorPattern.FindAllStringSubmatchIndex(body, -1)
+	seen := make(map[string]bool)
+	var entries []DirListEntry
+	for i, anchor := range anchors {
+		href := html.UnescapeString(body[anchor[2]:anchor[3]])
+		// 跳过排序链接、页内锚点和上级目录
+		if strings.HasPrefix(href, "?") || strings.HasPrefix(href, "#") || strings.HasPrefix(href, "..") {
+			continue
+		}
+		ref, err := neturl.Parse(href)
+		if err != nil {
+			continue
+		}
+		resolved := base.ResolveReference(ref)
+		if resolved.Host != base.Host || !strings.HasPrefix(resolved.Path, basePath) || resolved.RawQuery != "" {
+			continue
+		}
+		name := strings.TrimPrefix(resolved.Path, basePath)
+		isDir := strings.HasSuffix(name, "/")
+		name = strings.TrimSuffix(name, "/")
+		if name == "" || strings.Contains(name, "/") || seen[name] {
+			continue
+		}
+		seen[name] = true
+		resolved.Fragment = ""
+
+		end := len(body)
+		if i+1 < len(anchors) {
+			end = anchors[i+1][0]
+		}
+		// 跳过链接文本，链接文本可能是截断的文件名，其中的日期不是修改时间
+		start := anchor[1]
+		if closing := dirListAnchorEndPattern.FindStringIndex(body[start:end]); closing != nil {
+			start += closing[0]
+		}
+		trailing := html.UnescapeString(dirListTagPattern.ReplaceAllString(body[start:end], " "))
+
+		entry := DirListEntry{Name: path.Base("/" + name), URL: resolved.String(), IsDir: isDir}
+		entry.ModTime, entry.Size = parseDirListDetails(trailing)
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// parseDirListDetails 从链接后面的文本中解析修改时间和文件大小，无法解析时返回零值
+func parseDirListDetails(text string) (time.Time, int64) {
+	text = strings.ReplaceAll(text, "\u00a0", " ")
+	for _, format := range dirListDateFormats {
+		loc := format.pattern.FindStringIndex(text)
+		if loc == nil {
+			continue
+		}
+		for _, layout := range format.layouts {
+			if modTime, err := time.Parse(layout, text[loc[0]:loc[1]]); err == nil {
+				var size int64
+				if matches := dirListSizePattern.FindStringSubmatch(text[loc[1]:]); len(matches) > 1 {
+					size, _ = strconv.ParseInt(matches[1], 10, 64)
+				}
+				return modTime, size
+			}
+		}
+	}
+	return time.Time{}, 0
+}
+
+// dirListMatcher 文件名匹配规则
+type dirListMatcher struct {
+	glob  string
+	regex *regexp.Regexp
+}
+
+// newDirListMatcher 根据版本提取关键字创建文件名匹配规则
+func newDirListMatcher(key string) (*dirListMatcher, error) {
+	key = strings.TrimSpace(key)
+	if key == "" || !dirListRegexpChars.MatchString(key) {
+		if _, err := path.Match(key, ""); err != nil {
+			return nil, fmt.Errorf("无效的文件匹配模式 %s: %v", key, err)
+		}
+		return &dirListMatcher{glob: key}, nil
+	}
+	re, err := regexp.Compile(key)
+	if err != nil {
+		return nil, fmt.Errorf("编译文件匹配正则表达式失败: %v", err)
+	}
+	return &dirListMatcher{regex: re}, nil
+}
+
+// match 检查文件名是否匹配，返回从文件名中提取的版本号
+func (m *dirListMatcher) match(name string) (string, bool) {
+	if m.regex != nil {
+		matches := m.regex.FindStringSubmatch(name)
+		if matches == nil {
+			return "", false
+		}
+		if len(matches) > 1 && matches[1] != "" {
+			return matches[1], true
+		}
+		return extractFeedVersion(dirListVersionPattern, name), true
+	}
+	if m.glob != "" {
+		if matched, _ := path.Match(strings.ToLower(m.glob), strings.ToLower(name)); !matched {
+			return "", false
+		}
+	}
+	return extractFeedVersion(dirListVersionPattern, name), true
+}
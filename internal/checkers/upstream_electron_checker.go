@@ -0,0 +1,238 @@
+package checkers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"aur-update-checker/internal/checkers/common"
+	versionProcessor "aur-update-checker/internal/checkers/version"
+	"aur-update-checker/internal/logger"
+
+	checkerInterfaces "aur-update-checker/internal/interfaces/checkers"
+)
+
+const (
+	// OptionFeed 软件包检查器选项：electron-builder更新文件名，如 latest-linux-arm64.yml
+	OptionFeed = "feed"
+
+	// defaultElectronFeed electron-builder为Linux生成的更新文件
+	defaultElectronFeed = "latest-linux.yml"
+	// fallbackElectronFeed 只发布了Windows更新文件的项目，版本号通常与Linux版本一致
+	fallbackElectronFeed = "latest.yml"
+	// maxElectronFeedSize 更新文件的最大读取长度
+	maxElectronFeedSize = 1 << 20
+)
+
+// electronGitHubRepoPattern 匹配GitHub仓库地址
+var electronGitHubRepoPattern = regexp.MustCompile(`^https?://github\.com/([^/]+)/([^/#?]+)`)
+
+// ElectronFeed electron-builder生成的更新文件（latest-linux.yml、latest.yml等）
+type ElectronFeed struct {
+	Version     string             `yaml:"version"`
+	Files       []ElectronFeedFile `yaml:"files"`
+	Path        string             `yaml:"path"`
+	SHA512      string             `yaml:"sha512"`
+	ReleaseDate string             `yaml:"releaseDate"`
+}
+
+// ElectronFeedFile 更新文件中列出的安装包
+type ElectronFeedFile struct {
+	URL    string `yaml:"url"`
+	SHA512 string `yaml:"sha512"`
+	Size   int64  `yaml:"size"`
+}
+
+// ElectronChecker electron-builder更新文件检查器
+type ElectronChecker struct {
+	*checkerInterfaces.BaseChecker
+	client *http.Client
+}
+
+// NewElectronChecker 创建electron-builder更新文件检查器
+func NewElectronChecker() *ElectronChecker {
+	return &ElectronChecker{
+		BaseChecker: checkerInterfaces.NewBaseChecker("electron"),
+		client:      &http.Client{},
+	}
+}
+
+// Supports 检查URL是否是electron-builder更新文件地址
+func (c *ElectronChecker) Supports(url string) bool {
+	name := strings.ToLower(path.Base(url))
+	return (strings.HasSuffix(name, ".yml") || strings.HasSuffix(name, ".yaml")) && (strings.HasPrefix(name, "latest") || strings.HasPrefix(name, "beta") || strings.HasPrefix(name, "alpha"))
+}
+
+// Priority 更新文件地址很明确，优先级高于通用HTTP检查器
+func (c *ElectronChecker) Priority() int {
+	return 70
+}
+
+// Check 实现检查器接口，从更新文件中获取版本
+func (c *ElectronChecker) Check(ctx context.Context, url, versionExtractKey string) (string, error) {
+	// 默认不检查测试版本
+	return c.CheckWithOption(ctx, url, versionExtractKey, 0)
+}
+
+// CheckWithOption 实现检查器接口，根据选项从更新文件中获取版本
+func (c *ElectronChecker) CheckWithOption(ctx context.Context, url, versionExtractKey string, checkTestVersion int) (string, error) {
+	return c.CheckWithVersionRef(ctx, url, versionExtractKey, "", checkTestVersion)
+}
+
+// CheckWithVersionRef 带选项和版本引用地检查上游版本
+func (c *ElectronChecker) CheckWithVersionRef(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (string, error) {
+	result, err := c.CheckWithResult(ctx, url, versionExtractKey, versionRef, checkTestVersion)
+	if err != nil {
+		return "", err
+	}
+	return result.Version, nil
+}
+
+// CheckWithResult 实现检查器接口，返回更新文件中的版本、发布时间以及安装包地址和sha512
+// URL可以是更新文件地址、GitHub仓库地址（使用最新发布中的更新文件）或更新文件所在的目录地址
+func (c *ElectronChecker) CheckWithResult(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (*common.UpstreamCheckResult, error) {
+	feedURLs := c.feedURLs(url, common.CheckOptionsFromContext(ctx).Get(OptionFeed, ""))
+	logger.GlobalLogger.Debugf("[electron] 开始检查更新文件 - URL: %s, 候选地址: %v", url, feedURLs)
+
+	var feed *ElectronFeed
+	var baseURL *neturl.URL
+	var err error
+	for _, feedURL := range feedURLs {
+		feed, baseURL, err = c.fetchFeed(ctx, feedURL)
+		if err == nil {
+			break
+		}
+		logger.GlobalLogger.Debugf("[electron] 获取更新文件失败(%s): %v", feedURL, err)
+	}
+	if err != nil {
+		logger.GlobalLogger.Errorf("[electron] 获取更新文件失败: %v", err)
+		return nil, fmt.Errorf("获取更新文件失败: %v", err)
+	}
+
+	if feed.Version == "" {
+		return nil, fmt.Errorf("更新文件中没有版本号")
+	}
+
+	version := c.BaseChecker.NormalizeVersionWithOption(feed.Version, checkTestVersion)
+	result := &common.UpstreamCheckResult{
+		Version:      version,
+		IsPrerelease: !versionProcessor.NewVersionComparator().IsStableVersion(feed.Version),
+		ReleaseDate:  common.ParseReleaseDate(feed.ReleaseDate),
+		SourceURL:    baseURL.String(),
+		Assets:       feed.assets(baseURL),
+	}
+
+	logger.GlobalLogger.Infof("[electron] 更新文件版本: %s，安装包数量: %d", result.Version, len(result.Assets))
+	return result, nil
+}
+
+// feedURLs 根据软件包URL推导更新文件地址，按顺序尝试
+func (c *ElectronChecker) feedURLs(url, feed string) []string {
+	url = strings.TrimSpace(url)
+
+	// 已经是更新文件地址
+	lower := strings.ToLower(url)
+	if strings.HasSuffix(lower, ".yml") || strings.HasSuffix(lower, ".yaml") {
+		return []string{url}
+	}
+
+	// GitHub仓库地址使用最新发布中的更新文件
+	base := strings.TrimSuffix(url, "/") + "/"
+	if matches := electronGitHubRepoPattern.FindStringSubmatch(url); matches != nil {
+		base = fmt.Sprintf("https://github.com/%s/%s/releases/latest/download/", matches[1], strings.TrimSuffix(matches[2], ".git"))
+	}
+
+	if feed != "" {
+		return []string{base + feed}
+	}
+	return []string{base + defaultElectronFeed, base + fallbackElectronFeed}
+}
+
+// fetchFeed 获取并解析更新文件，同时返回解析安装包相对地址所用的基础地址
+func (c *ElectronChecker) fetchFeed(ctx context.Context, feedURL string) (*ElectronFeed, *neturl.URL, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", feedURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("创建请求失败: %v", err)
+	}
+	req.Header.Set("User-Agent", "aur-update-checker")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("请求失败: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("请求失败，状态码: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxElectronFeedSize))
+	if err != nil {
+		return nil, nil, fmt.Errorf("读取响应体失败: %v", err)
+	}
+
+	var feed ElectronFeed
+	if err := yaml.Unmarshal(body, &feed); err != nil {
+		return nil, nil, fmt.Errorf("解析更新文件失败: %v", err)
+	}
+
+	return &feed, feedBaseURL(resp), nil
+}
+
+// feedBaseURL 返回更新文件的地址，用于解析安装包的相对地址
+// 重定向到其他主机（如GitHub发布附件的CDN签名地址）时，使用原主机上的最后一个地址，
+// 这样GitHub的 releases/latest/download 会解析为带具体版本标签的下载地址
+func feedBaseURL(resp *http.Response) *neturl.URL {
+	originalHost := resp.Request.URL.Host
+	var chain []*http.Request
+	for req := resp.Request; req != nil; {
+		chain = append(chain, req)
+		if req.Response == nil {
+			originalHost = req.URL.Host
+			break
+		}
+		req = req.Response.Request
+	}
+
+	for _, req := range chain {
+		if req.URL.Host == originalHost {
+			return req.URL
+		}
+	}
+	return resp.Request.URL
+}
+
+// assets 将更新文件中的安装包转换为发布附件，path字段指向的安装包作为主文件
+func (f *ElectronFeed) assets(baseURL *neturl.URL) []common.ReleaseAsset {
+	files := f.Files
+	// 旧版本electron-builder只有path和sha512字段
+	if len(files) == 0 && f.Path != "" {
+		files = []ElectronFeedFile{{URL: f.Path, SHA512: f.SHA512}}
+	}
+
+	var assets []common.ReleaseAsset
+	for _, file := range files {
+		if file.URL == "" {
+			continue
+		}
+		downloadURL := file.URL
+		if ref, err := neturl.Parse(file.URL); err == nil {
+			downloadURL = baseURL.ResolveReference(ref).String()
+		}
+		assets = append(assets, common.ReleaseAsset{
+			Name:        path.Base(file.URL),
+			DownloadURL: downloadURL,
+			Size:        file.Size,
+			SHA512:      file.SHA512,
+			Default:     file.URL == f.Path || len(files) == 1,
+		})
+	}
+	return assets
+}
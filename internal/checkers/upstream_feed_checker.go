@@ -0,0 +1,299 @@
+package checkers
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"aur-update-checker/internal/checkers/common"
+	versionProcessor "aur-update-checker/internal/checkers/version"
+	"aur-update-checker/internal/logger"
+
+	checkerInterfaces "aur-update-checker/internal/interfaces/checkers"
+)
+
+var (
+	// feedURLPattern 匹配常见的RSS/Atom订阅地址，如 releases.atom、/feed、/rss.xml
+	feedURLPattern = regexp.MustCompile(`(?i)(?:\.(?:atom|rss)|/(?:feed|rss|atom)(?:\.xml)?/?|/index\.xml)(?:[?#].*)?$`)
+	// feedVersionPattern 版本提取关键字为空时从标题或链接中提取版本号，包括预发布后缀，如 v1.2.0-rc.1
+	feedVersionPattern = regexp.MustCompile(`(?i)\bv?(\d+(?:\.\d+)+(?:[-.]?(?:alpha|beta|rc|pre|preview|dev)[.-]?\d*)?)`)
+)
+
+// FeedDocument RSS 2.0、RSS 1.0（RDF）和Atom订阅的通用结构
+type FeedDocument struct {
+	// ChannelItems RSS 2.0的条目
+	ChannelItems []FeedItem `xml:"channel>item"`
+	// Items RSS 1.0的条目，与channel同级
+	Items []FeedItem `xml:"item"`
+	// Entries Atom的条目
+	Entries []FeedItem `xml:"entry"`
+}
+
+// FeedItem RSS条目或Atom条目，两种格式的字段按本地名称解析
+type FeedItem struct {
+	Title     string     `xml:"title"`
+	Links     []FeedLink `xml:"link"`
+	PubDate   string     `xml:"pubDate"`
+	Date      string     `xml:"date"` // dc:date
+	Updated   string     `xml:"updated"`
+	Published string     `xml:"published"`
+	Enclosure struct {
+		URL    string `xml:"url,attr"`
+		Length int64  `xml:"length,attr"`
+	} `xml:"enclosure"`
+}
+
+// FeedLink RSS的链接是元素文本，Atom的链接在href属性中
+type FeedLink struct {
+	Href   string `xml:"href,attr"`
+	Rel    string `xml:"rel,attr"`
+	Length int64  `xml:"length,attr"`
+	Text   string `xml:",chardata"`
+}
+
+// FeedChecker RSS/Atom订阅检查器
+type FeedChecker struct {
+	*checkerInterfaces.BaseChecker
+	client *http.Client
+}
+
+// NewFeedChecker 创建RSS/Atom订阅检查器
+func NewFeedChecker() *FeedChecker {
+	return &FeedChecker{
+		BaseChecker: checkerInterfaces.NewBaseChecker("feed"),
+		client:      &http.Client{},
+	}
+}
+
+// Supports 检查URL是否像RSS/Atom订阅地址
+func (c *FeedChecker) Supports(url string) bool {
+	return feedURLPattern.MatchString(url)
+}
+
+// Priority 订阅地址通常不与专用检查器冲突，优先级低于平台检查器、高于通用HTTP检查器
+func (c *FeedChecker) Priority() int {
+	return 60
+}
+
+// Check 实现检查器接口，获取订阅中的最新版本
+func (c *FeedChecker) Check(ctx context.Context, url, versionExtractKey string) (string, error) {
+	// 默认不检查测试版本
+	return c.CheckWithOption(ctx, url, versionExtractKey, 0)
+}
+
+// CheckWithOption 实现检查器接口，根据选项获取订阅中的最新版本
+func (c *FeedChecker) CheckWithOption(ctx context.Context, url, versionExtractKey string, checkTestVersion int) (string, error) {
+	return c.CheckWithVersionRef(ctx, url, versionExtractKey, "", checkTestVersion)
+}
+
+// CheckWithVersionRef 带选项和版本引用地检查上游版本
+func (c *FeedChecker) CheckWithVersionRef(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (string, error) {
+	result, err := c.CheckWithResult(ctx, url, versionExtractKey, versionRef, checkTestVersion)
+	if err != nil {
+		return "", err
+	}
+	return result.Version, nil
+}
+
+// CheckWithResult 实现检查器接口，从RSS/Atom订阅的条目中选出版本号最大的条目
+// versionExtractKey 是匹配条目标题或链接的正则表达式，有捕获组时使用第一个捕获组作为版本号，否则使用整个匹配；
+// 为空时从标题（标题中没有时从链接）中提取版本号。发布时间使用条目的 updated/published 或 pubDate
+func (c *FeedChecker) CheckWithResult(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (*common.UpstreamCheckResult, error) {
+	logger.GlobalLogger.Debugf("[feed] 开始检查订阅 - URL: %s, 版本提取关键字: %s", url, versionExtractKey)
+
+	pattern := feedVersionPattern
+	if versionExtractKey != "" {
+		re, err := regexp.Compile(versionExtractKey)
+		if err != nil {
+			logger.GlobalLogger.Errorf("[feed] 编译版本提取正则表达式失败: %v", err)
+			return nil, fmt.Errorf("编译版本提取正则表达式失败: %v", err)
+		}
+		pattern = re
+	}
+
+	items, err := c.fetchItems(ctx, url)
+	if err != nil {
+		logger.GlobalLogger.Errorf("[feed] 获取订阅失败: %v", err)
+		return nil, fmt.Errorf("获取订阅失败: %v", err)
+	}
+
+	result, err := c.selectItem(items, pattern, checkTestVersion)
+	if err != nil {
+		logger.GlobalLogger.Errorf("[feed] %v", err)
+		return nil, err
+	}
+
+	logger.GlobalLogger.Infof("[feed] 从%d个条目中选择 %s，版本: %s", len(items), result.TagName, result.Version)
+	return result, nil
+}
+
+// selectItem 从条目中提取版本号，返回版本号最大的条目，版本相同时选择发布时间最新的条目
+func (c *FeedChecker) selectItem(items []FeedItem, pattern *regexp.Regexp, checkTestVersion int) (*common.UpstreamCheckResult, error) {
+	comparator := versionProcessor.NewVersionComparator()
+
+	var candidates []*common.UpstreamCheckResult
+	for _, item := range items {
+		title := strings.TrimSpace(item.Title)
+		link := item.link()
+
+		version := extractFeedVersion(pattern, title)
+		if version == "" {
+			version = extractFeedVersion(pattern, link)
+		}
+		if version == "" {
+			continue
+		}
+
+		stable := comparator.IsStableVersion(version)
+		if checkTestVersion != 1 && !stable {
+			continue
+		}
+
+		result := &common.UpstreamCheckResult{
+			Version:      c.BaseChecker.NormalizeVersionWithOption(version, checkTestVersion),
+			TagName:      title,
+			IsPrerelease: !stable,
+			ReleaseDate:  item.releaseDate(),
+			SourceURL:    link,
+		}
+		if enclosureURL, length := item.enclosure(); enclosureURL != "" {
+			result.Assets = []common.ReleaseAsset{{
+				Name:        enclosureURL[strings.LastIndex(enclosureURL, "/")+1:],
+				DownloadURL: enclosureURL,
+				Size:        length,
+				Default:     true,
+			}}
+		}
+		candidates = append(candidates, result)
+	}
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("在%d个订阅条目中未找到符合条件的版本", len(items))
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if result := comparator.CompareVersions(candidates[i].Version, candidates[j].Version); result != 0 {
+			return result > 0
+		}
+		return candidates[i].ReleaseDate.After(candidates[j].ReleaseDate)
+	})
+	return candidates[0], nil
+}
+
+// extractFeedVersion 使用正则表达式从文本中提取版本号，有捕获组时使用第一个捕获组
+func extractFeedVersion(pattern *regexp.Regexp, text string) string {
+	if text == "" {
+		return ""
+	}
+	matches := pattern.FindStringSubmatch(text)
+	if matches == nil {
+		return ""
+	}
+	if len(matches) > 1 {
+		return strings.TrimSpace(matches[1])
+	}
+	return strings.TrimSpace(matches[0])
+}
+
+// link 返回条目的页面地址，Atom优先使用 rel="alternate" 的链接
+func (item FeedItem) link() string {
+	fallback := ""
+	for _, link := range item.Links {
+		if link.Href == "" {
+			if text := strings.TrimSpace(link.Text); text != "" && fallback == "" {
+				fallback = text
+			}
+			continue
+		}
+		if link.Rel == "" || link.Rel == "alternate" {
+			return link.Href
+		}
+		if fallback == "" && link.Rel != "enclosure" {
+			fallback = link.Href
+		}
+	}
+	return fallback
+}
+
+// enclosure 返回条目附带的文件，RSS使用enclosure元素，Atom使用 rel="enclosure" 的链接
+func (item FeedItem) enclosure() (string, int64) {
+	if item.Enclosure.URL != "" {
+		return item.Enclosure.URL, item.Enclosure.Length
+	}
+	for _, link := range item.Links {
+		if link.Rel == "enclosure" && link.Href != "" {
+			return link.Href, link.Length
+		}
+	}
+	return "", 0
+}
+
+// releaseDate 返回条目的发布时间，依次使用Atom的updated、published和RSS的pubDate、dc:date
+func (item FeedItem) releaseDate() time.Time {
+	for _, value := range []string{item.Updated, item.Published, item.PubDate, item.Date} {
+		if parsed := common.ParseReleaseDate(value); !parsed.IsZero() {
+			return parsed
+		}
+	}
+	return time.Time{}
+}
+
+// fetchItems 下载并解析订阅，返回全部条目
+func (c *FeedChecker) fetchItems(ctx context.Context, url string) ([]FeedItem, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %v", err)
+	}
+	req.Header.Set("User-Agent", "aur-update-checker")
+	req.Header.Set("Accept", "application/atom+xml, application/rss+xml, application/xml;q=0.9, */*;q=0.8")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("请求失败: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("请求失败，状态码: %d", resp.StatusCode)
+	}
+
+	var document FeedDocument
+	decoder := xml.NewDecoder(resp.Body)
+	decoder.Strict = false
+	decoder.CharsetReader = feedCharsetReader
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("解析订阅失败: %v", err)
+	}
+
+	items := append(append(document.ChannelItems, document.Items...), document.Entries...)
+	if len(items) == 0 {
+		return nil, fmt.Errorf("订阅中没有条目")
+	}
+	return items, nil
+}
+
+// feedCharsetReader 支持声明为ISO-8859-1的订阅，其他非UTF-8编码返回错误
+func feedCharsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(charset) {
+	case "utf-8", "utf8", "us-ascii", "ascii":
+		return input, nil
+	case "iso-8859-1", "iso8859-1", "latin1", "latin-1":
+		data, err := io.ReadAll(input)
+		if err != nil {
+			return nil, err
+		}
+		// ISO-8859-1的每个字节与Unicode的前256个码位一一对应
+		runes := make([]rune, len(data))
+		for i, b := range data {
+			runes[i] = rune(b)
+		}
+		return strings.NewReader(string(runes)), nil
+	}
+	return nil, fmt.Errorf("不支持的订阅编码: %s", charset)
+}
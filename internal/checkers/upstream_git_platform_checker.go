@@ -1,21 +1,65 @@
 package checkers
 
 import (
+	"aur-update-checker/internal/checkers/common"
 	"aur-update-checker/internal/logger"
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"sync"
+	"time"
 
 	checkerInterfaces "aur-update-checker/internal/interfaces/checkers"
 )
 
+// defaultRateLimitMaxWait 触发频率限制后，检查器在本次检查中最多原地等待的时间
+// 超过该时间的等待交给调用方重新排队处理
+const defaultRateLimitMaxWait = 30 * time.Second
+
 // GitPlatformRelease Git平台发布信息的通用结构
 type GitPlatformRelease struct {
-	TagName string `json:"tag_name"`
-	Name    string `json:"name"`
-	HtmlUrl string `json:"html_url"`
-	WebUrl  string `json:"web_url"` // GitLab使用web_url而不是html_url
+	TagName     string             `json:"tag_name"`
+	Name        string             `json:"name"`
+	HtmlUrl     string             `json:"html_url"`
+	WebUrl      string             `json:"web_url"` // GitLab使用web_url而不是html_url
+	Prerelease  bool               `json:"prerelease"`
+	PublishedAt string             `json:"published_at"`
+	CreatedAt   string             `json:"created_at"` // Gitee没有published_at
+	Assets      []GitPlatformAsset `json:"assets"`
+}
+
+// GitPlatformAsset Git平台发布附件的通用结构（GitHub、Gitee、Gitea）
+type GitPlatformAsset struct {
+	Name               string `json:"name"`
+	BrowserDownloadURL string `json:"browser_download_url"`
+	Size               int64  `json:"size"`
+}
+
+// toCheckResult 转换为通用的检查结果
+func (r *GitPlatformRelease) toCheckResult(version string) *common.UpstreamCheckResult {
+	publishedAt := r.PublishedAt
+	if publishedAt == "" {
+		publishedAt = r.CreatedAt
+	}
+	result := &common.UpstreamCheckResult{
+		Version:      version,
+		TagName:      r.TagName,
+		IsPrerelease: r.Prerelease,
+		ReleaseDate:  common.ParseReleaseDate(publishedAt),
+		SourceURL:    r.HtmlUrl,
+	}
+	for _, asset := range r.Assets {
+		result.Assets = append(result.Assets, common.ReleaseAsset{
+			Name:        asset.Name,
+			DownloadURL: asset.BrowserDownloadURL,
+			Size:        asset.Size,
+		})
+	}
+	return result
 }
 
 // GitPlatformChecker Git平台检查器接口，定义了各平台需要实现的特定方法
@@ -28,6 +72,8 @@ type GitPlatformChecker interface {
 	GetLatestReleaseAPIURL(owner, repo string) string
 	// 获取最新标签的API URL
 	GetLatestTagsAPIURL(owner, repo string) string
+	// 获取分支提交列表的API URL，每页一个提交，branch为空时使用默认分支
+	GetCommitsAPIURL(owner, repo, branch string) string
 	// 设置HTTP请求的特定头信息
 	SetRequestHeaders(req *http.Request)
 	// 检查是否支持给定的URL
@@ -36,22 +82,41 @@ type GitPlatformChecker interface {
 	GetPriority() int
 }
 
+// GitPlatformHostBinder 支持自建实例的Git平台检查器可实现此接口
+// 返回绑定了URL所在主机的平台检查器，使API URL方法能够拼出正确的主机地址
+type GitPlatformHostBinder interface {
+	BindHost(url string) (GitPlatformChecker, error)
+}
+
 // BaseGitPlatformChecker Git平台检查器的基类，包含共同的方法和逻辑
 type BaseGitPlatformChecker struct {
 	*checkerInterfaces.BaseChecker
 	client         *http.Client
 	platformChecker GitPlatformChecker
+
+	// 频率限制状态，同一检查器实例的所有检查共享
+	rateLimitMutex   sync.Mutex
+	rateLimitReset   time.Time
+	rateLimitMaxWait time.Duration
 }
 
 // NewBaseGitPlatformChecker 创建Git平台检查器基类
 func NewBaseGitPlatformChecker(platformChecker GitPlatformChecker) *BaseGitPlatformChecker {
 	return &BaseGitPlatformChecker{
-		BaseChecker:     checkerInterfaces.NewBaseChecker(platformChecker.GetPlatformName()),
-		client:          &http.Client{},
-		platformChecker: platformChecker,
+		BaseChecker:      checkerInterfaces.NewBaseChecker(platformChecker.GetPlatformName()),
+		client:           &http.Client{},
+		platformChecker:  platformChecker,
+		rateLimitMaxWait: defaultRateLimitMaxWait,
 	}
 }
 
+// SetRateLimitMaxWait 设置触发频率限制后在检查器内原地等待的最长时间
+func (c *BaseGitPlatformChecker) SetRateLimitMaxWait(wait time.Duration) {
+	c.rateLimitMutex.Lock()
+	defer c.rateLimitMutex.Unlock()
+	c.rateLimitMaxWait = wait
+}
+
 // Check 实现检查器接口，检查Git平台项目版本
 func (c *BaseGitPlatformChecker) Check(ctx context.Context, url, versionExtractKey string) (string, error) {
 	// 默认不检查测试版本
@@ -60,31 +125,77 @@ func (c *BaseGitPlatformChecker) Check(ctx context.Context, url, versionExtractK
 
 // CheckWithOption 实现检查器接口，根据选项检查Git平台项目版本
 func (c *BaseGitPlatformChecker) CheckWithOption(ctx context.Context, url, versionExtractKey string, checkTestVersion int) (string, error) {
+	result, err := c.checkResult(ctx, url, versionExtractKey, checkTestVersion)
+	if err != nil {
+		return "", err
+	}
+	return result.Version, nil
+}
+
+// CheckWithResult 实现检查器接口，返回最新发布的版本、发布时间和附件列表
+func (c *BaseGitPlatformChecker) CheckWithResult(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (*common.UpstreamCheckResult, error) {
+	return c.checkResult(ctx, url, versionExtractKey, checkTestVersion)
+}
+
+// checkResult 先通过发布获取版本，失败时通过标签获取版本
+func (c *BaseGitPlatformChecker) checkResult(ctx context.Context, url, versionExtractKey string, checkTestVersion int) (*common.UpstreamCheckResult, error) {
+	// 获取与URL对应的平台检查器
+	platform, err := c.bindPlatform(url)
+	if err != nil {
+		platformName := c.platformChecker.GetPlatformName()
+		errMsg := fmt.Errorf("解析%s URL失败: %v", platformName, err)
+		logger.GlobalLogger.Errorf("[%s] %v", platformName, errMsg)
+		return nil, errMsg
+	}
+
 	// 解析URL获取owner和repo
-	owner, repo, err := c.platformChecker.ParsePlatformURL(url)
+	owner, repo, err := platform.ParsePlatformURL(url)
 	if err != nil {
 		platformName := c.platformChecker.GetPlatformName()
 		errMsg := fmt.Errorf("解析%s URL失败: %v", platformName, err)
 		logger.GlobalLogger.Errorf("[%s] %v", platformName, errMsg)
-		return "", errMsg
+		return nil, errMsg
+	}
+
+	// gitcommit模式：使用分支的最新提交作为版本
+	if isGitCommitMode(ctx) {
+		commit, err := c.getLatestCommit(ctx, platform, owner, repo)
+		if err != nil {
+			return nil, err
+		}
+		if commit.SourceURL == "" {
+			commit.SourceURL = url
+		}
+		return commit, nil
 	}
 
 	// 方法1: 通过API获取latest release
-	version, err := c.getLatestReleaseWithOption(ctx, owner, repo, versionExtractKey, checkTestVersion)
-	if err == nil && version != "" {
-		return version, nil
+	release, err := c.getLatestReleaseWithOption(ctx, platform, owner, repo, versionExtractKey, checkTestVersion)
+	if err == nil && release.Version != "" {
+		if release.SourceURL == "" {
+			release.SourceURL = url
+		}
+		return release, nil
+	}
+	// 频率限制时继续请求标签也会失败，直接交给调用方重新排队
+	if common.IsRateLimitError(err) {
+		return nil, err
 	}
 
 	// 方法2: 如果获取的latest release失败，则通过API获取latest tag
-	version, err = c.getLatestTagWithOption(ctx, owner, repo, versionExtractKey, checkTestVersion)
-	if err == nil && version != "" {
-		return version, nil
+	tag, err := c.getLatestTagWithOption(ctx, platform, owner, repo, versionExtractKey, checkTestVersion)
+	if err == nil && tag.Version != "" {
+		tag.SourceURL = url
+		return tag, nil
+	}
+	if common.IsRateLimitError(err) {
+		return nil, err
 	}
 
 	// 所有检查方法均失败
 	platformName := c.platformChecker.GetPlatformName()
 	logger.GlobalLogger.Errorf("[%s] 所有%s检查方法均失败", platformName, platformName)
-	return "", fmt.Errorf("所有%s检查方法均失败", platformName)
+	return nil, fmt.Errorf("所有%s检查方法均失败", platformName)
 }
 
 // CheckWithVersionRef 带选项和版本引用地检查上游版本
@@ -103,101 +214,193 @@ func (c *BaseGitPlatformChecker) Priority() int {
 	return c.platformChecker.GetPriority()
 }
 
-// getLatestReleaseWithOption 根据选项获取最新发布版本
-func (c *BaseGitPlatformChecker) getLatestReleaseWithOption(ctx context.Context, owner, repo, versionExtractKey string, checkTestVersion int) (string, error) {
-	platformName := c.platformChecker.GetPlatformName()
-	apiURL := c.platformChecker.GetLatestReleaseAPIURL(owner, repo)
+// bindPlatform 获取与URL对应的平台检查器，支持自建实例的平台会绑定URL所在的主机
+func (c *BaseGitPlatformChecker) bindPlatform(url string) (GitPlatformChecker, error) {
+	if binder, ok := c.platformChecker.(GitPlatformHostBinder); ok {
+		return binder.BindHost(url)
+	}
+	return c.platformChecker, nil
+}
+
+// doAPIRequest 发送平台API请求，处理频率限制并检查响应状态码
+// 返回的响应状态码一定是200，调用方负责关闭响应体
+func (c *BaseGitPlatformChecker) doAPIRequest(ctx context.Context, platform GitPlatformChecker, apiURL string) (*http.Response, error) {
+	return c.doAPIRequestWithBody(ctx, platform, "GET", apiURL, nil)
+}
+
+// doAPIRequestWithBody 发送带JSON请求体的平台API请求，body为nil时不发送请求体
+func (c *BaseGitPlatformChecker) doAPIRequestWithBody(ctx context.Context, platform GitPlatformChecker, method, apiURL string, body []byte) (*http.Response, error) {
+	platformName := platform.GetPlatformName()
+
+	// 如果当前处于频率限制中，等待重置或直接返回频率限制错误
+	if err := c.waitForRateLimit(ctx, apiURL); err != nil {
+		return nil, err
+	}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, apiURL, bodyReader)
 	if err != nil {
 		errMsg := fmt.Errorf("创建请求失败: %v", err)
 		logger.GlobalLogger.Errorf("[%s] %v", platformName, errMsg)
-		return "", errMsg
+		return nil, errMsg
 	}
 
 	// 设置平台特定的请求头
-	c.platformChecker.SetRequestHeaders(req)
+	platform.SetRequestHeaders(req)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("请求失败: %v", err)
+		return nil, fmt.Errorf("请求失败: %v", err)
 	}
-	defer resp.Body.Close()
+
+	// 记录响应中的频率限制信息
+	limited := c.updateRateLimit(platformName, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("API请求失败，状态码: %d", resp.StatusCode)
+		resp.Body.Close()
+		if limited && (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests) {
+			return nil, common.NewRateLimitError(apiURL, c.getRateLimitReset())
+		}
+		return nil, fmt.Errorf("API请求失败，状态码: %d", resp.StatusCode)
 	}
 
-	var release GitPlatformRelease
-	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
-		errMsg := fmt.Errorf("解析响应失败: %v", err)
-		logger.GlobalLogger.Errorf("[%s] %v", platformName, errMsg)
-		return "", errMsg
+	return resp, nil
+}
+
+// updateRateLimit 解析响应头中的频率限制信息，返回当前是否已触发频率限制
+// 支持 X-RateLimit-Remaining/X-RateLimit-Reset（GitHub、Gitea）以及 Retry-After
+func (c *BaseGitPlatformChecker) updateRateLimit(platformName string, resp *http.Response) bool {
+	var resetAt time.Time
+
+	remaining := resp.Header.Get("X-RateLimit-Remaining")
+	if remaining == "0" {
+		if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			resetAt = time.Unix(reset, 0)
+		} else {
+			// 没有重置时间时保守地等待一分钟
+			resetAt = time.Now().Add(time.Minute)
+		}
 	}
 
-	// 如果versionExtractKey为空，直接返回tag_name并进行规范化
-	if versionExtractKey == "" {
-		return c.BaseChecker.NormalizeVersionWithOption(release.TagName, checkTestVersion), nil
+	if retryAfter, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && resp.StatusCode != http.StatusOK {
+		if candidate := time.Now().Add(time.Duration(retryAfter) * time.Second); candidate.After(resetAt) {
+			resetAt = candidate
+		}
 	}
 
-	// 使用versionExtractKey提取版本
-	version, err := c.BaseChecker.ExtractVersionFromContent(release.TagName, versionExtractKey)
-	if err != nil {
-		return "", err
+	if resetAt.IsZero() {
+		return false
 	}
-	return c.BaseChecker.NormalizeVersionWithOption(version, checkTestVersion), nil
+
+	c.rateLimitMutex.Lock()
+	c.rateLimitReset = resetAt
+	c.rateLimitMutex.Unlock()
+
+	logger.GlobalLogger.Warnf("[%s] API请求次数已用尽，将于 %s 重置", platformName, resetAt.Format("2006-01-02 15:04:05"))
+	return true
 }
 
-// getLatestTagWithOption 根据选项获取最新标签
-func (c *BaseGitPlatformChecker) getLatestTagWithOption(ctx context.Context, owner, repo, versionExtractKey string, checkTestVersion int) (string, error) {
-	platformName := c.platformChecker.GetPlatformName()
-	apiURL := c.platformChecker.GetLatestTagsAPIURL(owner, repo)
+// getRateLimitReset 获取频率限制的重置时间
+func (c *BaseGitPlatformChecker) getRateLimitReset() time.Time {
+	c.rateLimitMutex.Lock()
+	defer c.rateLimitMutex.Unlock()
+	return c.rateLimitReset
+}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
-	if err != nil {
-		errMsg := fmt.Errorf("创建请求失败: %v", err)
-		logger.GlobalLogger.Errorf("[%s] %v", platformName, errMsg)
-		return "", errMsg
+// waitForRateLimit 处于频率限制中时，等待时间不超过上限则原地等待，否则返回频率限制错误
+func (c *BaseGitPlatformChecker) waitForRateLimit(ctx context.Context, apiURL string) error {
+	c.rateLimitMutex.Lock()
+	resetAt := c.rateLimitReset
+	maxWait := c.rateLimitMaxWait
+	c.rateLimitMutex.Unlock()
+
+	wait := time.Until(resetAt)
+	if wait <= 0 {
+		return nil
 	}
 
-	// 设置平台特定的请求头
-	c.platformChecker.SetRequestHeaders(req)
+	if wait > maxWait {
+		return common.NewRateLimitError(apiURL, resetAt)
+	}
 
-	resp, err := c.client.Do(req)
+	logger.GlobalLogger.Infof("[%s] 等待频率限制重置，约 %v", c.platformChecker.GetPlatformName(), wait.Round(time.Second))
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// getLatestReleaseWithOption 根据选项获取最新发布版本
+func (c *BaseGitPlatformChecker) getLatestReleaseWithOption(ctx context.Context, platform GitPlatformChecker, owner, repo, versionExtractKey string, checkTestVersion int) (*common.UpstreamCheckResult, error) {
+	platformName := platform.GetPlatformName()
+	apiURL := platform.GetLatestReleaseAPIURL(owner, repo)
+
+	resp, err := c.doAPIRequest(ctx, platform, apiURL)
 	if err != nil {
-		return "", fmt.Errorf("请求失败: %v", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("API请求失败，状态码: %d", resp.StatusCode)
+	var release GitPlatformRelease
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		errMsg := fmt.Errorf("解析响应失败: %v", err)
+		logger.GlobalLogger.Errorf("[%s] %v", platformName, errMsg)
+		return nil, errMsg
 	}
 
-	var tags []struct {
-		Name string `json:"name"`
+	version, err := c.versionFromTag(release.TagName, versionExtractKey, checkTestVersion)
+	if err != nil {
+		return nil, err
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&tags); err != nil {
-		errMsg := fmt.Errorf("解析响应失败: %v", err)
-		logger.GlobalLogger.Errorf("[%s] %v", platformName, errMsg)
-		return "", errMsg
+	return release.toCheckResult(version), nil
+}
+
+// getLatestTagWithOption 根据选项获取最新标签
+// 获取全部标签后按软件包的标签选择策略选出版本，默认按版本号选择最大的标签
+func (c *BaseGitPlatformChecker) getLatestTagWithOption(ctx context.Context, platform GitPlatformChecker, owner, repo, versionExtractKey string, checkTestVersion int) (*common.UpstreamCheckResult, error) {
+	platformName := platform.GetPlatformName()
+	apiURL := platform.GetLatestTagsAPIURL(owner, repo)
+
+	tags, err := c.fetchAllTags(ctx, platform, apiURL)
+	if err != nil {
+		return nil, err
 	}
 
 	if len(tags) == 0 {
 		errMsg := fmt.Errorf("未找到任何标签")
 		logger.GlobalLogger.Errorf("[%s] %v", platformName, errMsg)
-		return "", errMsg
+		return nil, errMsg
 	}
 
-	// 获取第一个标签（最新的）
-	latestTag := tags[0].Name
+	strategy := common.CheckOptionsFromContext(ctx).Get(OptionTagStrategy, TagStrategyVersion)
+	result, err := c.selectTag(ctx, platform, tags, versionExtractKey, checkTestVersion, strategy)
+	if err != nil {
+		logger.GlobalLogger.Errorf("[%s] %v", platformName, err)
+		return nil, err
+	}
+	return result, nil
+}
 
+// versionFromTag 从标签名中提取并规范化版本号
+func (c *BaseGitPlatformChecker) versionFromTag(tagName, versionExtractKey string, checkTestVersion int) (string, error) {
 	// 如果versionExtractKey为空，直接返回标签名并进行规范化
 	if versionExtractKey == "" {
-		return c.BaseChecker.NormalizeVersionWithOption(latestTag, checkTestVersion), nil
+		return c.BaseChecker.NormalizeVersionWithOption(tagName, checkTestVersion), nil
 	}
 
 	// 使用versionExtractKey提取版本
-	version, err := c.BaseChecker.ExtractVersionFromContent(latestTag, versionExtractKey)
+	version, err := c.BaseChecker.ExtractVersionFromContent(tagName, versionExtractKey)
 	if err != nil {
 		return "", err
 	}
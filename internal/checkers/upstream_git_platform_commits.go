@@ -0,0 +1,150 @@
+package checkers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+
+	"aur-update-checker/internal/checkers/common"
+	"aur-update-checker/internal/logger"
+)
+
+const (
+	// OptionMode 软件包检查器选项：检查模式
+	OptionMode = "mode"
+	// OptionBranch 软件包检查器选项：gitcommit模式检查的分支，默认为仓库的默认分支
+	OptionBranch = "branch"
+
+	// ModeGitCommit 检查分支的最新提交，版本号格式与makepkg的VCS软件包一致：r<提交数>.<短哈希>
+	ModeGitCommit = "gitcommit"
+
+	// shortCommitHashLength 版本号中提交哈希的长度，与git rev-parse --short的默认值一致
+	shortCommitHashLength = 7
+)
+
+// linkLastPattern 匹配Link响应头中的最后一页地址
+var linkLastPattern = regexp.MustCompile(`<([^>]+)>\s*;\s*rel="?last"?`)
+
+// GitPlatformCommit Git平台提交信息的通用结构
+type GitPlatformCommit struct {
+	SHA           string `json:"sha"`            // GitHub、Gitee、Gitea
+	ID            string `json:"id"`             // GitLab
+	HtmlUrl       string `json:"html_url"`       // GitHub、Gitee、Gitea
+	WebUrl        string `json:"web_url"`        // GitLab
+	CommittedDate string `json:"committed_date"` // GitLab
+	Commit        struct {
+		Committer struct {
+			Date string `json:"date"`
+		} `json:"committer"`
+	} `json:"commit"`
+}
+
+// Hash 返回提交哈希
+func (c *GitPlatformCommit) Hash() string {
+	if c.SHA != "" {
+		return c.SHA
+	}
+	return c.ID
+}
+
+// Date 返回提交时间
+func (c *GitPlatformCommit) Date() string {
+	if c.CommittedDate != "" {
+		return c.CommittedDate
+	}
+	return c.Commit.Committer.Date
+}
+
+// isGitCommitMode 检查软件包是否配置了gitcommit检查模式
+func isGitCommitMode(ctx context.Context) bool {
+	return common.CheckOptionsFromContext(ctx).Get(OptionMode, "") == ModeGitCommit
+}
+
+// withBranchParam 在提交列表API URL中设置分支参数，branch为空时原样返回
+func withBranchParam(apiURL, param, branch string) string {
+	if branch == "" {
+		return apiURL
+	}
+	return setQueryParam(apiURL, param, branch)
+}
+
+// getLatestCommit 获取分支的最新提交，返回makepkg风格的版本号 r<提交数>.<短哈希>
+// 提交列表每页只请求一个提交，提交总数从分页响应头中获取
+func (c *BaseGitPlatformChecker) getLatestCommit(ctx context.Context, platform GitPlatformChecker, owner, repo string) (*common.UpstreamCheckResult, error) {
+	platformName := platform.GetPlatformName()
+	branch := common.CheckOptionsFromContext(ctx).Get(OptionBranch, "")
+	apiURL := platform.GetCommitsAPIURL(owner, repo, branch)
+
+	resp, err := c.doAPIRequest(ctx, platform, apiURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var commits []GitPlatformCommit
+	if err := json.NewDecoder(resp.Body).Decode(&commits); err != nil {
+		errMsg := fmt.Errorf("解析响应失败: %v", err)
+		logger.GlobalLogger.Errorf("[%s] %v", platformName, errMsg)
+		return nil, errMsg
+	}
+
+	if len(commits) == 0 || commits[0].Hash() == "" {
+		errMsg := fmt.Errorf("分支 '%s' 中未找到任何提交", branch)
+		logger.GlobalLogger.Errorf("[%s] %v", platformName, errMsg)
+		return nil, errMsg
+	}
+
+	count, err := commitCount(resp, len(commits))
+	if err != nil {
+		logger.GlobalLogger.Errorf("[%s] %v", platformName, err)
+		return nil, err
+	}
+
+	commit := commits[0]
+	hash := commit.Hash()
+	if len(hash) > shortCommitHashLength {
+		hash = hash[:shortCommitHashLength]
+	}
+
+	sourceURL := commit.HtmlUrl
+	if sourceURL == "" {
+		sourceURL = commit.WebUrl
+	}
+
+	version := fmt.Sprintf("r%d.%s", count, hash)
+	logger.GlobalLogger.Infof("[%s] 分支 '%s' 的最新提交: %s", platformName, branch, version)
+	return &common.UpstreamCheckResult{
+		Version:     version,
+		ReleaseDate: common.ParseReleaseDate(commit.Date()),
+		SourceURL:   sourceURL,
+	}, nil
+}
+
+// commitCount 从每页一个提交的分页响应头中获取提交总数
+// 支持 X-Total-Count（Gitea）、X-Total（GitLab）、total_count（Gitee）以及 Link 响应头的最后一页（GitHub）
+func commitCount(resp *http.Response, pageSize int) (int, error) {
+	for _, header := range []string{"X-Total-Count", "X-Total", "total_count"} {
+		if total, err := strconv.Atoi(resp.Header.Get(header)); err == nil && total > 0 {
+			return total, nil
+		}
+	}
+
+	if matches := linkLastPattern.FindStringSubmatch(resp.Header.Get("Link")); len(matches) > 1 {
+		if parsed, err := url.Parse(matches[1]); err == nil {
+			if page, err := strconv.Atoi(parsed.Query().Get("page")); err == nil && page > 0 {
+				return page, nil
+			}
+		}
+	}
+
+	// 只有一页时平台不返回分页信息
+	if !linkNextPattern.MatchString(resp.Header.Get("Link")) && resp.Header.Get("X-Next-Page") == "" {
+		return pageSize, nil
+	}
+
+	return 0, fmt.Errorf("无法从响应头中获取提交数量")
+}
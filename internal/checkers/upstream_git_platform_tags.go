@@ -0,0 +1,247 @@
+package checkers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"aur-update-checker/internal/checkers/common"
+	versionProcessor "aur-update-checker/internal/checkers/version"
+	"aur-update-checker/internal/logger"
+)
+
+const (
+	// OptionTagStrategy 软件包检查器选项：标签选择策略
+	OptionTagStrategy = "tag_strategy"
+
+	// TagStrategyVersion 按版本号选择最大的标签（默认）
+	TagStrategyVersion = "version"
+	// TagStrategyDate 按提交时间选择最新的标签
+	TagStrategyDate = "date"
+	// TagStrategyAPI 使用API返回的第一个标签
+	TagStrategyAPI = "api"
+
+	// maxTagPages 获取标签时最多请求的页数
+	maxTagPages = 10
+	// maxTagDateLookups 标签列表不含日期时，最多单独查询日期的标签数量
+	maxTagDateLookups = 30
+)
+
+// linkNextPattern 匹配Link响应头中的下一页地址
+var linkNextPattern = regexp.MustCompile(`<([^>]+)>\s*;\s*rel="?next"?`)
+
+// GitPlatformTag Git平台标签信息的通用结构
+type GitPlatformTag struct {
+	Name   string `json:"name"`
+	Commit struct {
+		SHA           string `json:"sha"`
+		URL           string `json:"url"`
+		CommittedDate string `json:"committed_date"` // GitLab
+		Created       string `json:"created"`        // Gitea
+		Date          string `json:"date"`           // Gitee
+	} `json:"commit"`
+}
+
+// CommitDate 返回标签对应提交的时间，平台未提供时返回空字符串
+func (t *GitPlatformTag) CommitDate() string {
+	for _, date := range []string{t.Commit.CommittedDate, t.Commit.Created, t.Commit.Date} {
+		if date != "" {
+			return date
+		}
+	}
+	return ""
+}
+
+// tagCandidate 通过过滤的候选标签
+type tagCandidate struct {
+	tag     *GitPlatformTag
+	version string
+	date    time.Time
+}
+
+// fetchAllTags 获取仓库的全部标签，按Link响应头或分页响应头翻页
+func (c *BaseGitPlatformChecker) fetchAllTags(ctx context.Context, platform GitPlatformChecker, apiURL string) ([]GitPlatformTag, error) {
+	var tags []GitPlatformTag
+
+	for page := 1; apiURL != "" && page <= maxTagPages; page++ {
+		resp, err := c.doAPIRequest(ctx, platform, apiURL)
+		if err != nil {
+			// 已经获取到部分标签时不因后续页失败而放弃，频率限制除外
+			if len(tags) > 0 && !common.IsRateLimitError(err) {
+				logger.GlobalLogger.Warnf("[%s] 获取第%d页标签失败，使用已获取的%d个标签: %v", platform.GetPlatformName(), page, len(tags), err)
+				break
+			}
+			return nil, err
+		}
+
+		var pageTags []GitPlatformTag
+		err = json.NewDecoder(resp.Body).Decode(&pageTags)
+		nextURL := nextPageURL(resp, apiURL)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("解析响应失败: %v", err)
+		}
+
+		tags = append(tags, pageTags...)
+		if len(pageTags) == 0 {
+			break
+		}
+		apiURL = nextURL
+	}
+
+	return tags, nil
+}
+
+// nextPageURL 根据响应头获取下一页的地址，没有下一页时返回空字符串
+// 支持 Link 响应头（GitHub、GitLab、Gitea）、X-Next-Page（GitLab）以及 total_page（Gitee）
+func nextPageURL(resp *http.Response, currentURL string) string {
+	if matches := linkNextPattern.FindStringSubmatch(resp.Header.Get("Link")); len(matches) > 1 {
+		return matches[1]
+	}
+
+	if next := resp.Header.Get("X-Next-Page"); next != "" {
+		return setQueryParam(currentURL, "page", next)
+	}
+
+	if total, err := strconv.Atoi(resp.Header.Get("total_page")); err == nil {
+		page := 1
+		if parsed, err := url.Parse(currentURL); err == nil {
+			if p, err := strconv.Atoi(parsed.Query().Get("page")); err == nil {
+				page = p
+			}
+		}
+		if page < total {
+			return setQueryParam(currentURL, "page", strconv.Itoa(page+1))
+		}
+	}
+
+	return ""
+}
+
+// setQueryParam 设置URL中的查询参数
+func setQueryParam(rawURL, key, value string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	query := parsed.Query()
+	query.Set(key, value)
+	parsed.RawQuery = query.Encode()
+	return parsed.String()
+}
+
+// selectTag 按标签选择策略从标签列表中选出版本
+// 标签先经过versionExtractKey提取版本，未开启检查测试版本时丢弃预发布版本
+func (c *BaseGitPlatformChecker) selectTag(ctx context.Context, platform GitPlatformChecker, tags []GitPlatformTag, versionExtractKey string, checkTestVersion int, strategy string) (*common.UpstreamCheckResult, error) {
+	platformName := platform.GetPlatformName()
+	comparator := versionProcessor.NewVersionComparator()
+
+	var candidates []*tagCandidate
+	for i := range tags {
+		version, err := c.versionFromTag(tags[i].Name, versionExtractKey, checkTestVersion)
+		if err != nil || version == "" {
+			continue
+		}
+		if checkTestVersion != 1 && !comparator.IsStableVersion(version) {
+			continue
+		}
+		candidates = append(candidates, &tagCandidate{tag: &tags[i], version: version})
+	}
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("在%d个标签中未找到符合条件的版本", len(tags))
+	}
+
+	// 按版本号从大到小排序，版本相同时保持API顺序
+	byVersion := make([]*tagCandidate, len(candidates))
+	copy(byVersion, candidates)
+	sort.SliceStable(byVersion, func(i, j int) bool {
+		return comparator.CompareVersions(byVersion[i].version, byVersion[j].version) > 0
+	})
+
+	var selected *tagCandidate
+	switch strategy {
+	case TagStrategyAPI:
+		selected = candidates[0]
+	case TagStrategyDate:
+		selected = c.newestTagByDate(ctx, platform, byVersion)
+		if selected == nil {
+			logger.GlobalLogger.Warnf("[%s] 无法获取标签的提交时间，改为按版本号选择", platformName)
+			selected = byVersion[0]
+		}
+	default:
+		if strategy != TagStrategyVersion {
+			logger.GlobalLogger.Warnf("[%s] 未知的标签选择策略 '%s'，使用按版本号选择", platformName, strategy)
+		}
+		selected = byVersion[0]
+	}
+
+	logger.GlobalLogger.Debugf("[%s] 标签选择策略 %s，从%d个候选标签中选择 %s", platformName, strategy, len(candidates), selected.tag.Name)
+	return selected.toCheckResult(), nil
+}
+
+// toCheckResult 转换为通用的检查结果，发布时间使用标签对应提交的时间
+func (t *tagCandidate) toCheckResult() *common.UpstreamCheckResult {
+	result := common.NewVersionResult(t.version, "")
+	result.TagName = t.tag.Name
+	result.ReleaseDate = t.date
+	if result.ReleaseDate.IsZero() {
+		result.ReleaseDate = common.ParseReleaseDate(t.tag.CommitDate())
+	}
+	return result
+}
+
+// newestTagByDate 返回提交时间最新的候选标签，候选标签按版本号从大到小排列
+// 平台的标签列表不含时间时（如GitHub），只查询版本号最大的若干个标签的提交时间
+func (c *BaseGitPlatformChecker) newestTagByDate(ctx context.Context, platform GitPlatformChecker, candidates []*tagCandidate) *tagCandidate {
+	lookups := 0
+	var newest *tagCandidate
+
+	for _, candidate := range candidates {
+		date := candidate.tag.CommitDate()
+		if date == "" && candidate.tag.Commit.URL != "" && lookups < maxTagDateLookups {
+			lookups++
+			date = c.fetchCommitDate(ctx, platform, candidate.tag.Commit.URL)
+		}
+
+		parsed := common.ParseReleaseDate(date)
+		if parsed.IsZero() {
+			continue
+		}
+		candidate.date = parsed
+
+		if newest == nil || candidate.date.After(newest.date) {
+			newest = candidate
+		}
+	}
+
+	return newest
+}
+
+// fetchCommitDate 通过提交API获取提交时间，失败时返回空字符串
+func (c *BaseGitPlatformChecker) fetchCommitDate(ctx context.Context, platform GitPlatformChecker, commitURL string) string {
+	resp, err := c.doAPIRequest(ctx, platform, commitURL)
+	if err != nil {
+		logger.GlobalLogger.Debugf("[%s] 获取提交时间失败: %v", platform.GetPlatformName(), err)
+		return ""
+	}
+	defer resp.Body.Close()
+
+	var commit struct {
+		Commit struct {
+			Committer struct {
+				Date string `json:"date"`
+			} `json:"committer"`
+		} `json:"commit"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&commit); err != nil {
+		return ""
+	}
+	return commit.Commit.Committer.Date
+}
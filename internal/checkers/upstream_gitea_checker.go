@@ -0,0 +1,149 @@
+package checkers
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"aur-update-checker/internal/checkers/common"
+	"aur-update-checker/internal/config"
+)
+
+// defaultGiteaHosts 默认支持的Gitea/Forgejo实例
+var defaultGiteaHosts = []string{"codeberg.org", "gitea.com"}
+
+// GiteaChecker Gitea检查器，同时支持Codeberg、Forgejo及自建Gitea实例
+type GiteaChecker struct {
+	*BaseGitPlatformChecker
+	hosts    []string
+	apiToken string
+	// baseURL 绑定的实例地址，如 https://codeberg.org，仅在BindHost返回的检查器中设置
+	baseURL string
+}
+
+// NewGiteaChecker 创建Gitea检查器
+func NewGiteaChecker() *GiteaChecker {
+	checker := &GiteaChecker{
+		hosts: defaultGiteaHosts,
+	}
+	checker.BaseGitPlatformChecker = NewBaseGitPlatformChecker(checker)
+	return checker
+}
+
+// ApplySettings 应用检查器配置，从CustomParams中读取实例列表和API令牌
+func (c *GiteaChecker) ApplySettings(settings config.CheckerSettings) {
+	if hosts := common.GetStringSliceParam(settings.CustomParams, "hosts"); len(hosts) > 0 {
+		c.hosts = hosts
+	}
+	c.apiToken = common.GetStringParam(settings.CustomParams, "api_token", "")
+}
+
+// GetPlatformName 实现GitPlatformChecker接口，返回平台名称
+func (c *GiteaChecker) GetPlatformName() string {
+	return "gitea"
+}
+
+// BindHost 实现GitPlatformHostBinder接口，返回绑定了URL所在实例的检查器
+func (c *GiteaChecker) BindHost(url string) (GitPlatformChecker, error) {
+	baseURL, _, _, err := c.parseGiteaURL(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GiteaChecker{
+		hosts:    c.hosts,
+		apiToken: c.apiToken,
+		baseURL:  baseURL,
+	}, nil
+}
+
+// ParsePlatformURL 实现GitPlatformChecker接口，解析Gitea URL获取owner和repo
+func (c *GiteaChecker) ParsePlatformURL(url string) (string, string, error) {
+	_, owner, repo, err := c.parseGiteaURL(url)
+	if err != nil {
+		return "", "", err
+	}
+	return owner, repo, nil
+}
+
+// parseGiteaURL 解析Gitea URL获取实例地址、owner和repo
+func (c *GiteaChecker) parseGiteaURL(url string) (string, string, string, error) {
+	re := regexp.MustCompile(`^(https?)://([^/]+)/([^/]+)/([^/?#]+)`)
+	matches := re.FindStringSubmatch(url)
+	if len(matches) < 5 {
+		return "", "", "", fmt.Errorf("无效的Gitea URL格式")
+	}
+
+	scheme, host := matches[1], matches[2]
+	if !c.isKnownHost(scheme, host) {
+		return "", "", "", fmt.Errorf("主机 %s 不在Gitea实例列表中", host)
+	}
+
+	owner := matches[3]
+	repo := strings.TrimSuffix(matches[4], ".git")
+
+	return fmt.Sprintf("%s://%s", scheme, host), owner, repo, nil
+}
+
+// isKnownHost 检查主机是否在配置的实例列表中
+// 列表项可以是单纯的主机名，也可以带有协议前缀（如 http://git.example.lan）
+func (c *GiteaChecker) isKnownHost(scheme, host string) bool {
+	for _, known := range c.hosts {
+		known = strings.TrimSuffix(strings.ToLower(known), "/")
+		if known == strings.ToLower(host) || known == strings.ToLower(scheme+"://"+host) {
+			return true
+		}
+	}
+	return false
+}
+
+// apiBaseURL 返回实例的API根地址，未绑定实例时使用列表中的第一个实例
+func (c *GiteaChecker) apiBaseURL() string {
+	baseURL := c.baseURL
+	if baseURL == "" && len(c.hosts) > 0 {
+		baseURL = c.hosts[0]
+		if !strings.Contains(baseURL, "://") {
+			baseURL = "https://" + baseURL
+		}
+	}
+	return strings.TrimSuffix(baseURL, "/") + "/api/v1"
+}
+
+// GetLatestReleaseAPIURL 实现GitPlatformChecker接口，返回Gitea最新发布版本的API URL
+func (c *GiteaChecker) GetLatestReleaseAPIURL(owner, repo string) string {
+	return fmt.Sprintf("%s/repos/%s/%s/releases/latest", c.apiBaseURL(), owner, repo)
+}
+
+// GetLatestTagsAPIURL 实现GitPlatformChecker接口，返回Gitea最新标签的API URL
+func (c *GiteaChecker) GetLatestTagsAPIURL(owner, repo string) string {
+	return fmt.Sprintf("%s/repos/%s/%s/tags?limit=50", c.apiBaseURL(), owner, repo)
+}
+
+// GetCommitsAPIURL 实现GitPlatformChecker接口，返回Gitea分支提交列表的API URL，branch为空时使用默认分支
+// 关闭提交统计和文件列表，减少大仓库的响应时间
+func (c *GiteaChecker) GetCommitsAPIURL(owner, repo, branch string) string {
+	return withBranchParam(fmt.Sprintf("%s/repos/%s/%s/commits?limit=1&stat=false&files=false&verification=false", c.apiBaseURL(), owner, repo), "sha", branch)
+}
+
+// SetRequestHeaders 实现GitPlatformChecker接口，设置Gitea API需要的请求头
+func (c *GiteaChecker) SetRequestHeaders(req *http.Request) {
+	req.Header.Set("User-Agent", "aur-update-checker")
+	req.Header.Set("Accept", "application/json")
+	// 配置了API令牌时使用令牌认证，私有仓库和频率限制较严的实例需要
+	if c.apiToken != "" {
+		req.Header.Set("Authorization", "token "+c.apiToken)
+	}
+}
+
+// Supports 实现GitPlatformChecker接口，检查此检查器是否支持给定的URL
+func (c *GiteaChecker) Supports(url string) bool {
+	_, _, _, err := c.parseGiteaURL(url)
+	return err == nil
+}
+
+// GetPriority 实现GitPlatformChecker接口，返回检查器的优先级
+func (c *GiteaChecker) GetPriority() int {
+	// 只匹配配置的实例，给予与Gitee相同的中等优先级
+	return 70 // 0-100范围，70为中等优先级
+}
@@ -48,7 +48,12 @@ func (c *GiteeChecker) GetLatestReleaseAPIURL(owner, repo string) string {
 
 // GetLatestTagsAPIURL 实现GitPlatformChecker接口，返回Gitee最新标签的API URL
 func (c *GiteeChecker) GetLatestTagsAPIURL(owner, repo string) string {
-	return fmt.Sprintf("https://gitee.com/api/v5/repos/%s/%s/tags", owner, repo)
+	return fmt.Sprintf("https://gitee.com/api/v5/repos/%s/%s/tags?per_page=100", owner, repo)
+}
+
+// GetCommitsAPIURL 实现GitPlatformChecker接口，返回Gitee分支提交列表的API URL，branch为空时使用默认分支
+func (c *GiteeChecker) GetCommitsAPIURL(owner, repo, branch string) string {
+	return withBranchParam(fmt.Sprintf("https://gitee.com/api/v5/repos/%s/%s/commits?per_page=1", owner, repo), "sha", branch)
 }
 
 // SetRequestHeaders 实现GitPlatformChecker接口，设置Gitee API需要的请求头
@@ -3,22 +3,43 @@ package checkers
 import (
 	"fmt"
 	"net/http"
+	"os"
 	"regexp"
 	"strings"
+	"time"
+
+	"aur-update-checker/internal/checkers/common"
+	"aur-update-checker/internal/config"
 )
 
 // GitHubChecker GitHub检查器
 type GitHubChecker struct {
 	*BaseGitPlatformChecker
+	apiToken string
 }
 
 // NewGitHubChecker 创建GitHub检查器
 func NewGitHubChecker() *GitHubChecker {
-	checker := &GitHubChecker{}
+	checker := &GitHubChecker{
+		// 未在配置文件中设置令牌时，使用环境变量GITHUB_TOKEN
+		apiToken: os.Getenv("GITHUB_TOKEN"),
+	}
 	checker.BaseGitPlatformChecker = NewBaseGitPlatformChecker(checker)
 	return checker
 }
 
+// ApplySettings 应用检查器配置，从CustomParams中读取API令牌和频率限制等待时间
+func (c *GitHubChecker) ApplySettings(settings config.CheckerSettings) {
+	if token := common.GetStringParam(settings.CustomParams, "api_token", ""); token != "" {
+		c.apiToken = token
+	}
+
+	// rate_limit_max_wait 单位为秒，超过该时间的频率限制交给调用方重新排队
+	if wait := common.GetIntParam(settings.CustomParams, "rate_limit_max_wait", -1); wait >= 0 {
+		c.SetRateLimitMaxWait(time.Duration(wait) * time.Second)
+	}
+}
+
 // GetPlatformName 实现GitPlatformChecker接口，返回平台名称
 func (c *GitHubChecker) GetPlatformName() string {
 	return "github"
@@ -48,13 +69,23 @@ func (c *GitHubChecker) GetLatestReleaseAPIURL(owner, repo string) string {
 
 // GetLatestTagsAPIURL 实现GitPlatformChecker接口，返回GitHub最新标签的API URL
 func (c *GitHubChecker) GetLatestTagsAPIURL(owner, repo string) string {
-	return fmt.Sprintf("https://api.github.com/repos/%s/%s/tags", owner, repo)
+	return fmt.Sprintf("https://api.github.com/repos/%s/%s/tags?per_page=100", owner, repo)
+}
+
+// GetCommitsAPIURL 实现GitPlatformChecker接口，返回GitHub分支提交列表的API URL，branch为空时使用默认分支
+func (c *GitHubChecker) GetCommitsAPIURL(owner, repo, branch string) string {
+	return withBranchParam(fmt.Sprintf("https://api.github.com/repos/%s/%s/commits?per_page=1", owner, repo), "sha", branch)
 }
 
 // SetRequestHeaders 实现GitPlatformChecker接口，设置GitHub API需要的请求头
 func (c *GitHubChecker) SetRequestHeaders(req *http.Request) {
 	// 设置GitHub API需要的User-Agent
 	req.Header.Set("User-Agent", "aur-update-checker")
+	req.Header.Set("Accept", "application/vnd.github+json")
+	// 使用令牌认证，将匿名的每小时60次请求限制提高到5000次
+	if c.apiToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiToken)
+	}
 }
 
 // Supports 实现GitPlatformChecker接口，检查此检查器是否支持给定的URL
@@ -0,0 +1,263 @@
+package checkers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"aur-update-checker/internal/checkers/common"
+	"aur-update-checker/internal/logger"
+)
+
+const (
+	// githubGraphQLURL GitHub GraphQL API地址
+	githubGraphQLURL = "https://api.github.com/graphql"
+	// githubGraphQLBatchSize 单次GraphQL查询最多包含的仓库数量
+	githubGraphQLBatchSize = 100
+	// githubGraphQLReleaseCount 每个仓库获取的发布数量
+	githubGraphQLReleaseCount = 10
+	// githubGraphQLTagCount 每个仓库获取的标签数量
+	githubGraphQLTagCount = 100
+	// githubGraphQLAssetCount 每个发布获取的附件数量
+	githubGraphQLAssetCount = 50
+)
+
+// githubGraphQLRepoFragment 每个仓库查询的字段，发布按创建时间倒序，标签按提交时间倒序
+var githubGraphQLRepoFragment = fmt.Sprintf(`fragment releaseFields on Release {
+  tagName isPrerelease isDraft publishedAt url
+  releaseAssets(first: %d) { nodes { name downloadUrl size } }
+}
+fragment repoFields on Repository {
+  latestRelease { ...releaseFields }
+  releases(first: %d, orderBy: {field: CREATED_AT, direction: DESC}) {
+    nodes { ...releaseFields }
+  }
+  refs(refPrefix: "refs/tags/", first: %d, orderBy: {field: TAG_COMMIT_DATE, direction: DESC}) {
+    pageInfo { hasNextPage }
+    nodes {
+      name
+      target {
+        ... on Commit { committedDate }
+        ... on Tag { target { ... on Commit { committedDate } } }
+      }
+    }
+  }
+}`, githubGraphQLAssetCount, githubGraphQLReleaseCount, githubGraphQLTagCount)
+
+// githubGraphQLRelease GraphQL返回的发布信息
+type githubGraphQLRelease struct {
+	TagName      string `json:"tagName"`
+	IsPrerelease bool   `json:"isPrerelease"`
+	IsDraft      bool   `json:"isDraft"`
+	PublishedAt  string `json:"publishedAt"`
+	URL          string `json:"url"`
+	Assets       struct {
+		Nodes []struct {
+			Name        string `json:"name"`
+			DownloadURL string `json:"downloadUrl"`
+			Size        int64  `json:"size"`
+		} `json:"nodes"`
+	} `json:"releaseAssets"`
+}
+
+// githubGraphQLRepository GraphQL返回的仓库信息
+type githubGraphQLRepository struct {
+	LatestRelease *githubGraphQLRelease `json:"latestRelease"`
+	Releases      struct {
+		Nodes []githubGraphQLRelease `json:"nodes"`
+	} `json:"releases"`
+	Refs struct {
+		PageInfo struct {
+			HasNextPage bool `json:"hasNextPage"`
+		} `json:"pageInfo"`
+		Nodes []struct {
+			Name   string `json:"name"`
+			Target struct {
+				CommittedDate string `json:"committedDate"`
+				// 附注标签指向标签对象，提交时间在其目标中
+				Target *struct {
+					CommittedDate string `json:"committedDate"`
+				} `json:"target"`
+			} `json:"target"`
+		} `json:"nodes"`
+	} `json:"refs"`
+}
+
+// githubGraphQLResponse GraphQL响应，data中的键为查询时为每个仓库设置的别名
+type githubGraphQLResponse struct {
+	Data   map[string]*githubGraphQLRepository `json:"data"`
+	Errors []struct {
+		Message string        `json:"message"`
+		Path    []interface{} `json:"path"`
+	} `json:"errors"`
+}
+
+// CheckBatch 实现BatchUpstreamChecker接口，通过GraphQL API批量检查GitHub仓库
+// 每次查询最多包含100个仓库，查询失败时整批结果都带有错误，由调用方改用REST API逐个检查
+func (c *GitHubChecker) CheckBatch(ctx context.Context, requests []common.BatchCheckRequest) []common.BatchCheckResult {
+	results := make([]common.BatchCheckResult, len(requests))
+
+	// GitHub GraphQL API不支持匿名访问
+	if c.apiToken == "" {
+		err := fmt.Errorf("GitHub GraphQL API需要配置API令牌")
+		for i := range results {
+			results[i].Err = err
+		}
+		return results
+	}
+
+	for start := 0; start < len(requests); start += githubGraphQLBatchSize {
+		end := start + githubGraphQLBatchSize
+		if end > len(requests) {
+			end = len(requests)
+		}
+
+		if err := c.checkGraphQLChunk(ctx, requests[start:end], results[start:end]); err != nil {
+			logger.GlobalLogger.Warnf("[github] GraphQL批量查询失败(%d个仓库): %v", end-start, err)
+			for i := start; i < end; i++ {
+				results[i].Err = err
+			}
+		}
+	}
+
+	return results
+}
+
+// checkGraphQLChunk 使用一次GraphQL查询检查一批仓库，结果写入results中对应的位置
+func (c *GitHubChecker) checkGraphQLChunk(ctx context.Context, requests []common.BatchCheckRequest, results []common.BatchCheckResult) error {
+	var query strings.Builder
+	aliases := make(map[string]int)
+
+	query.WriteString("query {\n")
+	for i, req := range requests {
+		// 批量查询不包含提交信息，gitcommit模式的软件包改用REST API检查
+		if req.Options.Get(OptionMode, "") == ModeGitCommit {
+			results[i].Err = fmt.Errorf("gitcommit模式需要通过REST API检查")
+			continue
+		}
+
+		owner, repo, err := c.ParsePlatformURL(req.URL)
+		if err != nil {
+			results[i].Err = fmt.Errorf("解析github URL失败: %v", err)
+			continue
+		}
+
+		alias := "r" + strconv.Itoa(i)
+		aliases[alias] = i
+		fmt.Fprintf(&query, "  %s: repository(owner: %s, name: %s) { ...repoFields }\n", alias, strconv.Quote(owner), strconv.Quote(repo))
+	}
+	query.WriteString("}\n")
+	query.WriteString(githubGraphQLRepoFragment)
+
+	if len(aliases) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(map[string]string{"query": query.String()})
+	if err != nil {
+		return fmt.Errorf("构建GraphQL查询失败: %v", err)
+	}
+
+	resp, err := c.doAPIRequestWithBody(ctx, c, "POST", githubGraphQLURL, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var response githubGraphQLResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return fmt.Errorf("解析响应失败: %v", err)
+	}
+
+	// 按别名整理错误信息，没有路径的错误表示整个查询失败
+	repoErrors := make(map[string]string)
+	for _, e := range response.Errors {
+		if len(e.Path) > 0 {
+			if alias, ok := e.Path[0].(string); ok {
+				repoErrors[alias] = e.Message
+				continue
+			}
+		}
+		if response.Data == nil {
+			return fmt.Errorf("GraphQL查询失败: %s", e.Message)
+		}
+	}
+
+	for alias, i := range aliases {
+		repository := response.Data[alias]
+		if repository == nil {
+			if msg, ok := repoErrors[alias]; ok {
+				results[i].Err = fmt.Errorf("GraphQL查询仓库失败: %s", msg)
+			} else {
+				results[i].Err = fmt.Errorf("GraphQL查询未返回仓库信息")
+			}
+			continue
+		}
+		results[i] = c.selectGraphQLVersion(ctx, repository, requests[i])
+	}
+
+	return nil
+}
+
+// selectGraphQLVersion 从GraphQL返回的仓库信息中选出版本
+// 与REST检查流程一致：优先使用发布版本，没有可用发布时使用最新标签
+func (c *GitHubChecker) selectGraphQLVersion(ctx context.Context, repository *githubGraphQLRepository, req common.BatchCheckRequest) common.BatchCheckResult {
+	// latestRelease 不包含预发布和草稿版本，检查测试版本时改用最新创建的非草稿发布
+	release := repository.LatestRelease
+	if req.CheckTestVersion == 1 {
+		for i := range repository.Releases.Nodes {
+			if !repository.Releases.Nodes[i].IsDraft {
+				release = &repository.Releases.Nodes[i]
+				break
+			}
+		}
+	}
+
+	if release != nil && release.TagName != "" {
+		version, err := c.versionFromTag(release.TagName, req.VersionExtractKey, req.CheckTestVersion)
+		if err == nil && version != "" {
+			result := &common.UpstreamCheckResult{
+				Version:      version,
+				TagName:      release.TagName,
+				IsPrerelease: release.IsPrerelease,
+				ReleaseDate:  common.ParseReleaseDate(release.PublishedAt),
+				SourceURL:    release.URL,
+			}
+			for _, asset := range release.Assets.Nodes {
+				result.Assets = append(result.Assets, common.ReleaseAsset{
+					Name:        asset.Name,
+					DownloadURL: asset.DownloadURL,
+					Size:        asset.Size,
+				})
+			}
+			return common.BatchCheckResult{Result: result}
+		}
+	}
+
+	if len(repository.Refs.Nodes) > 0 {
+		strategy := req.Options.Get(OptionTagStrategy, TagStrategyVersion)
+		// 标签按提交时间倒序返回，标签超过一页时只有按时间选择的结果是可靠的，其余策略改用REST获取全部标签
+		if repository.Refs.PageInfo.HasNextPage && strategy != TagStrategyDate {
+			return common.BatchCheckResult{Err: fmt.Errorf("标签数量超过%d个，需要通过REST API获取全部标签", githubGraphQLTagCount)}
+		}
+
+		tags := make([]GitPlatformTag, len(repository.Refs.Nodes))
+		for i, node := range repository.Refs.Nodes {
+			tags[i].Name = node.Name
+			tags[i].Commit.CommittedDate = node.Target.CommittedDate
+			if node.Target.Target != nil {
+				tags[i].Commit.CommittedDate = node.Target.Target.CommittedDate
+			}
+		}
+
+		tag, err := c.selectTag(ctx, c, tags, req.VersionExtractKey, req.CheckTestVersion, strategy)
+		if err == nil && tag.Version != "" {
+			tag.SourceURL = req.URL
+			return common.BatchCheckResult{Result: tag}
+		}
+	}
+
+	return common.BatchCheckResult{Err: fmt.Errorf("所有github检查方法均失败")}
+}
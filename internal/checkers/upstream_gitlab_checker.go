@@ -7,18 +7,35 @@ import (
 	"net/http"
 	"regexp"
 	"strings"
+
+	"aur-update-checker/internal/checkers/common"
+	"aur-update-checker/internal/logger"
 )
 
 // GitLabRelease GitLab发布信息
 type GitLabRelease struct {
-	TagName string `json:"tag_name"`
-	Name    string `json:"name"`
-	WebUrl  string `json:"web_url"`
+	TagName         string `json:"tag_name"`
+	Name            string `json:"name"`
+	WebUrl          string `json:"web_url"`
+	ReleasedAt      string `json:"released_at"`
+	UpcomingRelease bool   `json:"upcoming_release"`
+	Links           struct {
+		Self string `json:"self"`
+	} `json:"_links"`
+	Assets struct {
+		Links []struct {
+			Name           string `json:"name"`
+			URL            string `json:"url"`
+			DirectAssetURL string `json:"direct_asset_url"`
+		} `json:"links"`
+	} `json:"assets"`
 }
 
 // GitLabChecker GitLab检查器
 type GitLabChecker struct {
 	*BaseGitPlatformChecker
+	// host 绑定的实例地址，如 https://gitlab.com，仅在BindHost返回的检查器中设置
+	host string
 }
 
 // NewGitLabChecker 创建GitLab检查器
@@ -33,6 +50,15 @@ func (c *GitLabChecker) GetPlatformName() string {
 	return "gitlab"
 }
 
+// BindHost 实现GitPlatformHostBinder接口，返回绑定了URL所在实例的检查器
+func (c *GitLabChecker) BindHost(url string) (GitPlatformChecker, error) {
+	host, _, _, err := c.parseGitLabURL(url)
+	if err != nil {
+		return nil, err
+	}
+	return &GitLabChecker{host: host}, nil
+}
+
 // ParsePlatformURL 实现GitPlatformChecker接口，解析GitLab URL获取owner和repo
 // 注意：GitLab需要host信息，所以我们只返回owner和repo，host信息在其他方法中处理
 func (c *GitLabChecker) ParsePlatformURL(url string) (string, string, error) {
@@ -52,19 +78,33 @@ func (c *GitLabChecker) ParsePlatformURL(url string) (string, string, error) {
 }
 
 // GetLatestReleaseAPIURL 实现GitPlatformChecker接口，返回GitLab最新发布版本的API URL
-// 注意：GitLab需要host信息，所以我们从完整URL中提取
+// 注意：GitLab需要host信息，需要先通过BindHost绑定URL所在的实例
 func (c *GitLabChecker) GetLatestReleaseAPIURL(owner, repo string) string {
-	// 由于GitLab API需要host信息，这里返回一个空字符串
-	// 实际的URL在CheckWithVersionRef方法中构建
-	return ""
+	// 未绑定实例时无法构建URL，返回空字符串
+	if c.host == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/api/v4/projects/%s%%2F%s/releases/permalink/latest", c.host, owner, repo)
 }
 
 // GetLatestTagsAPIURL 实现GitPlatformChecker接口，返回GitLab最新标签的API URL
-// 注意：GitLab需要host信息，所以我们从完整URL中提取
+// 注意：GitLab需要host信息，需要先通过BindHost绑定URL所在的实例
 func (c *GitLabChecker) GetLatestTagsAPIURL(owner, repo string) string {
-	// 由于GitLab API需要host信息，这里返回一个空字符串
-	// 实际的URL在CheckWithVersionRef方法中构建
-	return ""
+	// 未绑定实例时无法构建URL，返回空字符串
+	if c.host == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/api/v4/projects/%s%%2F%s/repository/tags?per_page=100", c.host, owner, repo)
+}
+
+// GetCommitsAPIURL 实现GitPlatformChecker接口，返回GitLab分支提交列表的API URL，branch为空时使用默认分支
+// 注意：GitLab需要host信息，需要先通过BindHost绑定URL所在的实例
+func (c *GitLabChecker) GetCommitsAPIURL(owner, repo, branch string) string {
+	// 未绑定实例时无法构建URL，返回空字符串
+	if c.host == "" {
+		return ""
+	}
+	return withBranchParam(fmt.Sprintf("%s/api/v4/projects/%s%%2F%s/repository/commits?per_page=1", c.host, owner, repo), "ref_name", branch)
 }
 
 // SetRequestHeaders 实现GitPlatformChecker接口，设置GitLab API需要的请求头
@@ -94,19 +134,41 @@ func (c *GitLabChecker) GetPriority() int {
 
 // CheckWithVersionRef 重写基类方法，实现GitLab特定的版本引用检查逻辑
 func (c *GitLabChecker) CheckWithVersionRef(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (string, error) {
+	result, err := c.CheckWithResult(ctx, url, versionExtractKey, versionRef, checkTestVersion)
+	if err != nil {
+		return "", err
+	}
+	return result.Version, nil
+}
+
+// CheckWithResult 重写基类方法，返回GitLab发布的版本、发布时间和附件链接
+func (c *GitLabChecker) CheckWithResult(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (*common.UpstreamCheckResult, error) {
 	// 解析GitLab URL获取host, owner和repo
 	host, owner, repo, err := c.parseGitLabURL(url)
 	if err != nil {
-		return "", fmt.Errorf("解析GitLab URL失败: %v", err)
+		return nil, fmt.Errorf("解析GitLab URL失败: %v", err)
+	}
+
+	// gitcommit模式：使用分支的最新提交作为版本
+	if isGitCommitMode(ctx) {
+		return c.getLatestCommit(ctx, &GitLabChecker{host: host}, owner, repo)
 	}
 
 	// 通过API获取latest release
-	version, err := c.getLatestReleaseWithVersionRef(ctx, host, owner, repo, versionExtractKey, versionRef, checkTestVersion)
-	if err != nil {
-		return "", fmt.Errorf("获取GitLab最新发布失败: %v", err)
+	release, err := c.getLatestReleaseWithVersionRef(ctx, host, owner, repo, versionExtractKey, versionRef, checkTestVersion)
+	if err == nil {
+		return release, nil
 	}
 
-	return version, nil
+	// 没有发布时通过标签获取版本
+	logger.GlobalLogger.Warnf("[gitlab] 获取最新发布失败，尝试使用标签: %v", err)
+	tag, tagErr := c.getLatestTagWithOption(ctx, &GitLabChecker{host: host}, owner, repo, versionExtractKey, checkTestVersion)
+	if tagErr != nil {
+		return nil, fmt.Errorf("获取GitLab最新发布失败: %v，获取标签失败: %v", err, tagErr)
+	}
+
+	tag.SourceURL = url
+	return tag, nil
 }
 
 // parseGitLabURL 解析GitLab URL获取host, owner和repo
@@ -138,13 +200,13 @@ func (c *GitLabChecker) parseGitLabURL(url string) (string, string, string, erro
 }
 
 // getLatestReleaseWithVersionRef 根据版本引用获取发布版本
-func (c *GitLabChecker) getLatestReleaseWithVersionRef(ctx context.Context, host, owner, repo, versionExtractKey, versionRef string, checkTestVersion int) (string, error) {
+func (c *GitLabChecker) getLatestReleaseWithVersionRef(ctx context.Context, host, owner, repo, versionExtractKey, versionRef string, checkTestVersion int) (*common.UpstreamCheckResult, error) {
 	// GitLab API URL格式
 	apiURL := fmt.Sprintf("%s/api/v4/projects/%s%%2F%s/releases", host, owner, repo)
 
 	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
 	if err != nil {
-		return "", fmt.Errorf("创建请求失败: %v", err)
+		return nil, fmt.Errorf("创建请求失败: %v", err)
 	}
 
 	// 设置GitLab API需要的请求头
@@ -152,21 +214,21 @@ func (c *GitLabChecker) getLatestReleaseWithVersionRef(ctx context.Context, host
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("请求失败: %v", err)
+		return nil, fmt.Errorf("请求失败: %v", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("API请求失败，状态码: %d", resp.StatusCode)
+		return nil, fmt.Errorf("API请求失败，状态码: %d", resp.StatusCode)
 	}
 
 	var releases []GitLabRelease
 	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
-		return "", fmt.Errorf("解析响应失败: %v", err)
+		return nil, fmt.Errorf("解析响应失败: %v", err)
 	}
 
 	if len(releases) == 0 {
-		return "", fmt.Errorf("未找到任何发布")
+		return nil, fmt.Errorf("未找到任何发布")
 	}
 
 	var targetRelease *GitLabRelease
@@ -189,17 +251,31 @@ func (c *GitLabChecker) getLatestReleaseWithVersionRef(ctx context.Context, host
 		targetRelease = &releases[0]
 	}
 
-	latestTag := targetRelease.TagName
-
-	// 如果versionExtractKey为空，直接返回标签名并进行规范化
-	if versionExtractKey == "" {
-		return c.BaseChecker.NormalizeVersionWithOption(latestTag, checkTestVersion), nil
+	version, err := c.versionFromTag(targetRelease.TagName, versionExtractKey, checkTestVersion)
+	if err != nil {
+		return nil, err
 	}
 
-	// 使用versionExtractKey提取版本
-	version, err := c.BaseChecker.ExtractVersionFromContent(latestTag, versionExtractKey)
-	if err != nil {
-		return "", err
+	result := &common.UpstreamCheckResult{
+		Version:      version,
+		TagName:      targetRelease.TagName,
+		IsPrerelease: targetRelease.UpcomingRelease,
+		ReleaseDate:  common.ParseReleaseDate(targetRelease.ReleasedAt),
+		SourceURL:    targetRelease.Links.Self,
+	}
+	if result.SourceURL == "" {
+		result.SourceURL = fmt.Sprintf("%s/%s/%s/-/releases/%s", host, owner, repo, targetRelease.TagName)
+	}
+	// GitLab的附件以链接形式保存，优先使用直接下载地址
+	for _, link := range targetRelease.Assets.Links {
+		downloadURL := link.DirectAssetURL
+		if downloadURL == "" {
+			downloadURL = link.URL
+		}
+		result.Assets = append(result.Assets, common.ReleaseAsset{
+			Name:        link.Name,
+			DownloadURL: downloadURL,
+		})
 	}
-	return c.BaseChecker.NormalizeVersionWithOption(version, checkTestVersion), nil
+	return result, nil
 }
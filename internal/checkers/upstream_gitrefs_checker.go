@@ -0,0 +1,289 @@
+package checkers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"aur-update-checker/internal/checkers/common"
+	versionProcessor "aur-update-checker/internal/checkers/version"
+	"aur-update-checker/internal/logger"
+
+	checkerInterfaces "aur-update-checker/internal/interfaces/checkers"
+)
+
+const (
+	// gitUploadPackAdvertisement 智能HTTP协议引用列表响应的Content-Type
+	gitUploadPackAdvertisement = "application/x-git-upload-pack-advertisement"
+	// gitTagRefPrefix 标签引用的前缀
+	gitTagRefPrefix = "refs/tags/"
+	// gitPeeledSuffix 附注标签解引用后指向提交的引用后缀
+	gitPeeledSuffix = "^{}"
+	// maxGitRefsResponseSize 引用列表响应的最大读取长度
+	maxGitRefsResponseSize = 32 << 20
+)
+
+// GitRefsChecker Git智能HTTP协议检查器
+// 通过 info/refs?service=git-upload-pack 获取仓库的引用列表，无需克隆仓库即可获取标签，
+// 适用于cgit、gitweb、kernel.org、sourcehut等没有发布API的Git服务器
+type GitRefsChecker struct {
+	*checkerInterfaces.BaseChecker
+	client *http.Client
+}
+
+// NewGitRefsChecker 创建Git智能HTTP协议检查器
+func NewGitRefsChecker() *GitRefsChecker {
+	return &GitRefsChecker{
+		BaseChecker: checkerInterfaces.NewBaseChecker("gitrefs"),
+		client:      &http.Client{},
+	}
+}
+
+// Supports 检查URL是否是HTTP(S)协议的Git仓库地址
+// 支持以.git结尾的地址，以及仓库地址不带.git后缀的sourcehut
+func (c *GitRefsChecker) Supports(url string) bool {
+	lower := strings.ToLower(url)
+	if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") {
+		return false
+	}
+	return strings.HasSuffix(strings.TrimSuffix(lower, "/"), ".git") || strings.Contains(lower, "://git.sr.ht/")
+}
+
+// Priority 低于各代码托管平台的检查器，平台地址优先使用平台API
+func (c *GitRefsChecker) Priority() int {
+	return 40
+}
+
+// Check 实现检查器接口，获取仓库的最新标签版本
+func (c *GitRefsChecker) Check(ctx context.Context, url, versionExtractKey string) (string, error) {
+	// 默认不检查测试版本
+	return c.CheckWithOption(ctx, url, versionExtractKey, 0)
+}
+
+// CheckWithOption 实现检查器接口，根据选项获取仓库的最新标签版本
+func (c *GitRefsChecker) CheckWithOption(ctx context.Context, url, versionExtractKey string, checkTestVersion int) (string, error) {
+	return c.CheckWithVersionRef(ctx, url, versionExtractKey, "", checkTestVersion)
+}
+
+// CheckWithVersionRef 带选项和版本引用地检查上游版本
+func (c *GitRefsChecker) CheckWithVersionRef(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (string, error) {
+	result, err := c.CheckWithResult(ctx, url, versionExtractKey, versionRef, checkTestVersion)
+	if err != nil {
+		return "", err
+	}
+	return result.Version, nil
+}
+
+// CheckWithResult 实现检查器接口，列出仓库的全部标签并选出版本号最大的标签
+// versionExtractKey 是标签过滤正则表达式，有捕获组时使用第一个捕获组作为版本号，为空时使用全部标签
+func (c *GitRefsChecker) CheckWithResult(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (*common.UpstreamCheckResult, error) {
+	logger.GlobalLogger.Debugf("[gitrefs] 开始检查Git仓库 - URL: %s, 标签过滤: %s, 检查测试版本: %d", url, versionExtractKey, checkTestVersion)
+
+	var filter *regexp.Regexp
+	if versionExtractKey != "" {
+		re, err := regexp.Compile(versionExtractKey)
+		if err != nil {
+			logger.GlobalLogger.Errorf("[gitrefs] 编译标签过滤正则表达式失败: %v", err)
+			return nil, fmt.Errorf("编译标签过滤正则表达式失败: %v", err)
+		}
+		filter = re
+	}
+
+	tags, err := c.fetchTags(ctx, url)
+	if err != nil {
+		logger.GlobalLogger.Errorf("[gitrefs] 获取标签列表失败: %v", err)
+		return nil, fmt.Errorf("获取标签列表失败: %v", err)
+	}
+
+	result, err := selectHighestTag(c.BaseChecker, tags, filter, checkTestVersion)
+	if err != nil {
+		logger.GlobalLogger.Errorf("[gitrefs] %v", err)
+		return nil, err
+	}
+
+	result.SourceURL = url
+	logger.GlobalLogger.Infof("[gitrefs] 从%d个标签中选择 %s，版本: %s", len(tags), result.TagName, result.Version)
+	return result, nil
+}
+
+// selectHighestTag 按过滤规则筛选标签，返回版本号最大的标签
+// filter有捕获组时使用第一个捕获组作为版本号，为nil时使用全部标签；Git仓库和容器镜像仓库的标签都按此规则选择
+func selectHighestTag(base *checkerInterfaces.BaseChecker, tags []string, filter *regexp.Regexp, checkTestVersion int) (*common.UpstreamCheckResult, error) {
+	comparator := versionProcessor.NewVersionComparator()
+
+	var candidates []*common.UpstreamCheckResult
+	for _, tag := range tags {
+		version := tag
+		if filter != nil {
+			matches := filter.FindStringSubmatch(tag)
+			if matches == nil {
+				continue
+			}
+			if len(matches) > 1 && matches[1] != "" {
+				version = matches[1]
+			}
+		}
+
+		version = base.NormalizeVersionWithOption(version, checkTestVersion)
+		// 跳过不含数字的标签，如 latest、stable
+		if !strings.ContainsAny(version, "0123456789") {
+			continue
+		}
+		stable := comparator.IsStableVersion(version)
+		if checkTestVersion != 1 && !stable {
+			continue
+		}
+
+		candidates = append(candidates, &common.UpstreamCheckResult{
+			Version:      version,
+			TagName:      tag,
+			IsPrerelease: !stable,
+		})
+	}
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("在%d个标签中未找到符合条件的版本", len(tags))
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return comparator.CompareVersions(candidates[i].Version, candidates[j].Version) > 0
+	})
+	return candidates[0], nil
+}
+
+// fetchTags 通过智能HTTP协议获取仓库的标签名称列表
+// 服务器不支持智能HTTP协议时，按哑协议的 info/refs 文本格式解析
+func (c *GitRefsChecker) fetchTags(ctx context.Context, repoURL string) ([]string, error) {
+	refsURL := strings.TrimSuffix(repoURL, "/") + "/info/refs?service=git-upload-pack"
+
+	req, err := http.NewRequestWithContext(ctx, "GET", refsURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %v", err)
+	}
+	// 部分Git托管服务只对git客户端返回智能HTTP协议响应
+	req.Header.Set("User-Agent", "git/2.45.0 (aur-update-checker)")
+	req.Header.Set("Accept", gitUploadPackAdvertisement+", */*")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("请求失败: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("请求失败，状态码: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxGitRefsResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("读取响应体失败: %v", err)
+	}
+
+	var refs []string
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), gitUploadPackAdvertisement) {
+		refs, err = parseRefAdvertisement(body)
+	} else {
+		logger.GlobalLogger.Debugf("[gitrefs] 服务器未使用智能HTTP协议，按哑协议解析引用列表")
+		refs, err = parseDumbRefs(body)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return tagNames(refs), nil
+}
+
+// parseRefAdvertisement 解析智能HTTP协议的引用列表响应，返回引用名称
+// 响应由pkt-line组成：4位十六进制长度（包含自身）加内容，0000为分隔包
+// 第一段是 "# service=git-upload-pack"，之后每行格式为 "<对象ID> <引用名>"，第一行的引用名后以NUL分隔附带服务器能力列表
+func parseRefAdvertisement(body []byte) ([]string, error) {
+	var refs []string
+
+	for len(body) > 0 {
+		if len(body) < 4 {
+			return nil, fmt.Errorf("pkt-line长度不完整")
+		}
+		length, err := strconv.ParseUint(string(body[:4]), 16, 16)
+		if err != nil {
+			return nil, fmt.Errorf("无效的pkt-line长度 %q", body[:4])
+		}
+
+		// 0000 分隔包，0001、0002 是协议v2的分隔符，均不含内容
+		if length < 4 {
+			body = body[4:]
+			continue
+		}
+		if int(length) > len(body) {
+			return nil, fmt.Errorf("pkt-line内容不完整")
+		}
+
+		line := body[4:length]
+		body = body[length:]
+
+		line = bytes.TrimSuffix(line, []byte("\n"))
+		if i := bytes.IndexByte(line, 0); i >= 0 {
+			line = line[:i]
+		}
+		if bytes.HasPrefix(line, []byte("#")) {
+			continue
+		}
+		if bytes.HasPrefix(line, []byte("ERR ")) {
+			return nil, fmt.Errorf("服务器返回错误: %s", line[4:])
+		}
+
+		fields := strings.Fields(string(line))
+		if len(fields) >= 2 {
+			refs = append(refs, fields[1])
+		}
+	}
+
+	return refs, nil
+}
+
+// parseDumbRefs 解析哑协议的 info/refs 文件，每行格式为 "<对象ID>\t<引用名>"
+func parseDumbRefs(body []byte) ([]string, error) {
+	var refs []string
+
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 {
+			refs = append(refs, fields[1])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("解析引用列表失败: %v", err)
+	}
+
+	// 不是引用列表时（如返回了网页），视为不支持的地址
+	if len(refs) == 0 && len(body) > 0 && !bytes.Contains(body, []byte("refs/")) {
+		return nil, fmt.Errorf("响应不是Git引用列表，请确认URL是Git仓库地址")
+	}
+	return refs, nil
+}
+
+// tagNames 从引用名称中提取去重后的标签名称，附注标签的解引用条目与标签本身合并
+func tagNames(refs []string) []string {
+	seen := make(map[string]bool)
+	var tags []string
+	for _, ref := range refs {
+		if !strings.HasPrefix(ref, gitTagRefPrefix) {
+			continue
+		}
+		tag := strings.TrimSuffix(strings.TrimPrefix(ref, gitTagRefPrefix), gitPeeledSuffix)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	return tags
+}
@@ -0,0 +1,203 @@
+package checkers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// pktLine 按pkt-line格式编码一行，长度包含4位长度前缀本身
+func pktLine(line string) string {
+	return fmt.Sprintf("%04x%s", len(line)+4, line)
+}
+
+// gitRefsTestAdvertisement git http-backend 对 info/refs?service=git-upload-pack 的响应
+var gitRefsTestAdvertisement = pktLine("# service=git-upload-pack\n") + "0000" +
+	pktLine("1111111111111111111111111111111111111111 HEAD\x00multi_ack thin-pack side-band side-band-64k ofs-delta shallow no-progress include-tag symref=HEAD:refs/heads/main agent=git/2.45.0\n") +
+	pktLine("1111111111111111111111111111111111111111 refs/heads/main\n") +
+	pktLine("2222222222222222222222222222222222222222 refs/pull/7/head\n") +
+	pktLine("3333333333333333333333333333333333333333 refs/tags/v1.0.0\n") +
+	pktLine("4444444444444444444444444444444444444444 refs/tags/v1.2.0\n") +
+	pktLine("5555555555555555555555555555555555555555 refs/tags/v1.2.0^{}\n") +
+	pktLine("6666666666666666666666666666666666666666 refs/tags/v1.10.0\n") +
+	pktLine("7777777777777777777777777777777777777777 refs/tags/v1.10.0^{}\n") +
+	pktLine("8888888888888888888888888888888888888888 refs/tags/v2.0.0-rc1\n") +
+	pktLine("9999999999999999999999999999999999999999 refs/tags/nightly\n") +
+	pktLine("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa refs/tags/tools-3.1\n") +
+	"0000"
+
+// gitRefsTestDumbRefs 哑协议服务器上的 info/refs 文件
+const gitRefsTestDumbRefs = "1111111111111111111111111111111111111111\trefs/heads/master\n" +
+	"3333333333333333333333333333333333333333\trefs/tags/release-0.9\n" +
+	"4444444444444444444444444444444444444444\trefs/tags/release-0.10\n" +
+	"5555555555555555555555555555555555555555\trefs/tags/release-0.10^{}\n"
+
+func newGitRefsTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/info/refs") || r.URL.Query().Get("service") != "git-upload-pack" {
+			http.NotFound(w, r)
+			return
+		}
+		switch strings.TrimSuffix(r.URL.Path, "/info/refs") {
+		case "/smart.git":
+			w.Header().Set("Content-Type", gitUploadPackAdvertisement)
+			w.Write([]byte(gitRefsTestAdvertisement))
+		case "/dumb.git":
+			w.Header().Set("Content-Type", "text/plain")
+			w.Write([]byte(gitRefsTestDumbRefs))
+		case "/denied.git":
+			w.Header().Set("Content-Type", gitUploadPackAdvertisement)
+			w.Write([]byte(pktLine("# service=git-upload-pack\n") + "0000" + pktLine("ERR access denied\n")))
+		case "/page.git":
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte("<html><body>Not a repository</body></html>"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGitRefsCheckerCheckWithResult(t *testing.T) {
+	server := newGitRefsTestServer(t)
+
+	tests := []struct {
+		name             string
+		repo             string
+		key              string
+		checkTestVersion int
+		wantVersion      string
+		wantTag          string
+		wantErr          bool
+	}{
+		{"智能协议按版本号选择最大的标签", "/smart.git", `^v(\d+\.\d+\.\d+)$`, 0, "1.10.0", "v1.10.0", false},
+		{"智能协议跳过测试版本", "/smart.git", `^v(.+)$`, 0, "1.10.0", "v1.10.0", false},
+		{"智能协议检查测试版本", "/smart.git", `^v(.+)$`, 1, "2.0.0-rc1", "v2.0.0-rc1", false},
+		{"过滤规则选择另一组标签", "/smart.git", `^tools-(.+)$`, 0, "3.1", "tools-3.1", false},
+		{"仓库地址末尾的/", "/smart.git/", `^v(\d+\.\d+\.\d+)$`, 0, "1.10.0", "v1.10.0", false},
+		{"哑协议", "/dumb.git", `^release-(.+)$`, 0, "0.10", "release-0.10", false},
+		{"没有匹配的标签", "/smart.git", `^stable-(.+)$`, 0, "", "", true},
+		{"无效的过滤规则", "/smart.git", `(`, 0, "", "", true},
+		{"服务器返回ERR", "/denied.git", "", 0, "", "", true},
+		{"不是Git仓库", "/page.git", "", 0, "", "", true},
+		{"仓库不存在", "/missing.git", "", 0, "", "", true},
+	}
+
+	checker := NewGitRefsChecker()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := checker.CheckWithResult(context.Background(), server.URL+tt.repo, tt.key, "", tt.checkTestVersion)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckWithResult(%q, %q) 错误 = %v，期望返回错误: %v", tt.repo, tt.key, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if result.Version != tt.wantVersion || result.TagName != tt.wantTag {
+				t.Errorf("CheckWithResult(%q, %q) = %q (%q)，期望 %q (%q)", tt.repo, tt.key, result.Version, result.TagName, tt.wantVersion, tt.wantTag)
+			}
+			if result.SourceURL != server.URL+tt.repo {
+				t.Errorf("SourceURL = %q，期望 %q", result.SourceURL, server.URL+tt.repo)
+			}
+		})
+	}
+}
+
+func TestParseRefAdvertisement(t *testing.T) {
+	refs, err := parseRefAdvertisement([]byte(gitRefsTestAdvertisement))
+	if err != nil {
+		t.Fatalf("parseRefAdvertisement 返回错误: %v", err)
+	}
+	want := []string{
+		"HEAD", "refs/heads/main", "refs/pull/7/head",
+		"refs/tags/v1.0.0", "refs/tags/v1.2.0", "refs/tags/v1.2.0^{}",
+		"refs/tags/v1.10.0", "refs/tags/v1.10.0^{}", "refs/tags/v2.0.0-rc1",
+		"refs/tags/nightly", "refs/tags/tools-3.1",
+	}
+	if !reflect.DeepEqual(refs, want) {
+		t.Errorf("parseRefAdvertisement = %q，期望 %q", refs, want)
+	}
+
+	// 空仓库只有能力列表，协议v2的分隔包不含内容
+	empty := pktLine("# service=git-upload-pack\n") + "0000" +
+		pktLine("0000000000000000000000000000000000000000 capabilities^{}\x00agent=git/2.45.0\n") + "0001" + "0000"
+	if refs, err := parseRefAdvertisement([]byte(empty)); err != nil || !reflect.DeepEqual(refs, []string{"capabilities^{}"}) {
+		t.Errorf("parseRefAdvertisement(空仓库) = %q, %v", refs, err)
+	}
+
+	for name, body := range map[string]string{
+		"ERR":    pktLine("ERR repository not exported\n"),
+		"长度不完整":  "00",
+		"无效的长度":  "zzzz",
+		"内容不完整":  "0040short",
+		"ERR在后面": pktLine("# service=git-upload-pack\n") + "0000" + pktLine("ERR upload-pack: not our ref\n"),
+	} {
+		if refs, err := parseRefAdvertisement([]byte(body)); err == nil {
+			t.Errorf("parseRefAdvertisement(%s) = %q，期望返回错误", name, refs)
+		}
+	}
+}
+
+func TestParseDumbRefs(t *testing.T) {
+	refs, err := parseDumbRefs([]byte(gitRefsTestDumbRefs))
+	if err != nil {
+		t.Fatalf("parseDumbRefs 返回错误: %v", err)
+	}
+	want := []string{"refs/heads/master", "refs/tags/release-0.9", "refs/tags/release-0.10", "refs/tags/release-0.10^{}"}
+	if !reflect.DeepEqual(refs, want) {
+		t.Errorf("parseDumbRefs = %q，期望 %q", refs, want)
+	}
+
+	if refs, err := parseDumbRefs(nil); err != nil || len(refs) != 0 {
+		t.Errorf("parseDumbRefs(空仓库) = %q, %v，期望没有引用", refs, err)
+	}
+	if _, err := parseDumbRefs([]byte("<html><body>login</body></html>")); err == nil {
+		t.Errorf("parseDumbRefs(网页) 期望返回错误")
+	}
+}
+
+func TestTagNames(t *testing.T) {
+	refs := []string{
+		"HEAD", "refs/heads/v9.9.9", "refs/tags/v1.0", "refs/tags/v1.1^{}", "refs/tags/v1.1",
+		"refs/tags/v1.0^{}", "refs/tags/", "refs/tags/feature/x",
+	}
+	want := []string{"v1.0", "v1.1", "feature/x"}
+	if got := tagNames(refs); !reflect.DeepEqual(got, want) {
+		t.Errorf("tagNames = %q，期望 %q", got, want)
+	}
+}
+
+func TestSelectHighestTag(t *testing.T) {
+	tags := []string{"v0.9.0", "v0.10.0", "v0.11.0-beta.1", "latest", "stable", "docs-2.0"}
+	tests := []struct {
+		name             string
+		filter           string
+		checkTestVersion int
+		want             string
+		wantErr          bool
+	}{
+		{"按版本号而不是字符串比较", `^v(.+)$`, 0, "0.10.0", false},
+		{"检查测试版本", `^v(.+)$`, 1, "0.11.0-beta.1", false},
+		{"没有捕获组时使用整个标签", `^v0\.9`, 0, "0.9.0", false},
+		{"跳过不含数字的标签", `^(latest|stable)$`, 0, "", true},
+	}
+	checker := NewGitRefsChecker()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := selectHighestTag(checker.BaseChecker, tags, regexp.MustCompile(tt.filter), tt.checkTestVersion)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("selectHighestTag(%q) 错误 = %v，期望返回错误: %v", tt.filter, err, tt.wantErr)
+			}
+			if err == nil && result.Version != tt.want {
+				t.Errorf("selectHighestTag(%q) = %q，期望 %q", tt.filter, result.Version, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,321 @@
+package checkers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/Masterminds/semver"
+
+	"aur-update-checker/internal/checkers/common"
+	"aur-update-checker/internal/config"
+	"aur-update-checker/internal/logger"
+
+	checkerInterfaces "aur-update-checker/internal/interfaces/checkers"
+)
+
+const (
+	// defaultGoProxy 默认的Go模块代理
+	defaultGoProxy = "https://proxy.golang.org"
+	// goIncompatibleSuffix 主版本号大于1但没有使用/vN模块路径的版本后缀
+	goIncompatibleSuffix = "+incompatible"
+)
+
+var (
+	// goPseudoVersionPattern 匹配Go伪版本号，如 v0.0.0-20240101000000-abcdef123456、v1.2.4-0.20240101000000-abcdef123456
+	// 与 golang.org/x/mod/module 中的判断规则一致
+	goPseudoVersionPattern = regexp.MustCompile(`^v[0-9]+\.(0\.0-|\d+\.\d+-([^+]*\.)?0\.)\d{14}-[A-Za-z0-9]+(\+[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?$`)
+	// goModulePagePattern 匹配pkg.go.dev的模块页面地址
+	goModulePagePattern = regexp.MustCompile(`^https?://pkg\.go\.dev/([^@?#]+)`)
+)
+
+// GoModuleInfo Go模块代理 @latest 和 @v/<版本>.info 的响应
+type GoModuleInfo struct {
+	Version string `json:"Version"`
+	Time    string `json:"Time"`
+}
+
+// GoModChecker Go模块代理检查器
+type GoModChecker struct {
+	*checkerInterfaces.BaseChecker
+	client *http.Client
+	proxy  string
+}
+
+// NewGoModChecker 创建Go模块代理检查器，默认使用GOPROXY环境变量中的第一个代理
+func NewGoModChecker() *GoModChecker {
+	return &GoModChecker{
+		BaseChecker: checkerInterfaces.NewBaseChecker("gomod"),
+		client:      &http.Client{},
+		proxy:       goProxyFromEnv(),
+	}
+}
+
+// ApplySettings 应用检查器配置，从CustomParams中读取模块代理地址
+func (c *GoModChecker) ApplySettings(settings config.CheckerSettings) {
+	if proxy := common.GetStringParam(settings.CustomParams, "proxy", ""); proxy != "" {
+		c.proxy = strings.TrimSuffix(proxy, "/")
+	}
+}
+
+// Supports 检查URL是否是pkg.go.dev或Go模块代理地址
+func (c *GoModChecker) Supports(url string) bool {
+	return goModulePagePattern.MatchString(url) || strings.Contains(url, "/@v/") || strings.HasSuffix(url, "/@latest")
+}
+
+// Priority 专用于Go模块，优先级高于通用检查器
+func (c *GoModChecker) Priority() int {
+	return 80
+}
+
+// Check 实现检查器接口，获取Go模块的最新版本
+func (c *GoModChecker) Check(ctx context.Context, url, versionExtractKey string) (string, error) {
+	// 默认不检查测试版本
+	return c.CheckWithOption(ctx, url, versionExtractKey, 0)
+}
+
+// CheckWithOption 实现检查器接口，根据选项获取Go模块的最新版本
+func (c *GoModChecker) CheckWithOption(ctx context.Context, url, versionExtractKey string, checkTestVersion int) (string, error) {
+	return c.CheckWithVersionRef(ctx, url, versionExtractKey, "", checkTestVersion)
+}
+
+// CheckWithVersionRef 带选项和版本引用地检查上游版本
+func (c *GoModChecker) CheckWithVersionRef(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (string, error) {
+	result, err := c.CheckWithResult(ctx, url, versionExtractKey, versionRef, checkTestVersion)
+	if err != nil {
+		return "", err
+	}
+	return result.Version, nil
+}
+
+// CheckWithResult 实现检查器接口，返回模块已发布的最大语义化版本、发布时间和源码包地址
+// 伪版本不参与选择；只有 +incompatible 版本的模块（如主版本号大于1但没有go.mod的旧仓库）才使用这些版本
+func (c *GoModChecker) CheckWithResult(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (*common.UpstreamCheckResult, error) {
+	module, err := goModulePath(url, versionExtractKey)
+	if err != nil {
+		logger.GlobalLogger.Errorf("[gomod] %v", err)
+		return nil, err
+	}
+	moduleURL := c.proxy + "/" + escapeGoModulePath(module)
+	logger.GlobalLogger.Debugf("[gomod] 开始检查Go模块 - 模块: %s, 代理: %s", module, c.proxy)
+
+	versions, err := c.fetchVersionList(ctx, moduleURL+"/@v/list")
+	if err != nil {
+		logger.GlobalLogger.Errorf("[gomod] 获取版本列表失败: %v", err)
+		return nil, fmt.Errorf("获取版本列表失败: %v", err)
+	}
+
+	latest, err := c.fetchInfo(ctx, moduleURL+"/@latest")
+	if err != nil {
+		logger.GlobalLogger.Warnf("[gomod] 获取 @latest 失败: %v", err)
+	}
+	// 代理的版本列表可能尚未收录刚发布的版本，@latest 是已发布版本时一并参与选择
+	if latest != nil && !goPseudoVersionPattern.MatchString(latest.Version) {
+		versions = append(versions, latest.Version)
+	}
+
+	selected := selectGoModuleVersion(versions, checkTestVersion)
+	if selected == "" {
+		logger.GlobalLogger.Errorf("[gomod] 模块 %s 没有已发布的版本", module)
+		return nil, fmt.Errorf("模块 %s 没有已发布的版本（只有伪版本）", module)
+	}
+
+	info := latest
+	if info == nil || info.Version != selected {
+		if info, err = c.fetchInfo(ctx, moduleURL+"/@v/"+escapeGoModulePath(selected)+".info"); err != nil {
+			logger.GlobalLogger.Warnf("[gomod] 获取版本 %s 的信息失败: %v", selected, err)
+		}
+	}
+
+	version := strings.TrimSuffix(selected, goIncompatibleSuffix)
+	result := &common.UpstreamCheckResult{
+		Version:      c.BaseChecker.NormalizeVersionWithOption(version, checkTestVersion),
+		TagName:      selected,
+		IsPrerelease: strings.Contains(version, "-"),
+		SourceURL:    fmt.Sprintf("https://pkg.go.dev/%s@%s", module, selected),
+		Assets: []common.ReleaseAsset{{
+			Name:        fmt.Sprintf("%s@%s.zip", strings.ReplaceAll(module, "/", "_"), selected),
+			DownloadURL: moduleURL + "/@v/" + escapeGoModulePath(selected) + ".zip",
+			Default:     true,
+		}},
+	}
+	if info != nil {
+		result.ReleaseDate = common.ParseReleaseDate(info.Time)
+	}
+
+	logger.GlobalLogger.Infof("[gomod] 模块 %s 的最新版本: %s (%s)", module, result.Version, selected)
+	return result, nil
+}
+
+// fetchVersionList 获取 @v/list 中的版本列表，每行一个版本
+func (c *GoModChecker) fetchVersionList(ctx context.Context, listURL string) ([]string, error) {
+	resp, err := c.get(ctx, listURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var versions []string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			versions = append(versions, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("读取版本列表失败: %v", err)
+	}
+	return versions, nil
+}
+
+// fetchInfo 获取 @latest 或 @v/<版本>.info 的版本信息
+func (c *GoModChecker) fetchInfo(ctx context.Context, infoURL string) (*GoModuleInfo, error) {
+	resp, err := c.get(ctx, infoURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var info GoModuleInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, fmt.Errorf("解析版本信息失败: %v", err)
+	}
+	return &info, nil
+}
+
+// get 发送GET请求，状态码不是200时返回错误
+// 模块代理对不存在的模块返回404或410，响应体中是错误原因
+func (c *GoModChecker) get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %v", err)
+	}
+	req.Header.Set("User-Agent", "aur-update-checker")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("请求失败: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		reason, _ := bufio.NewReader(resp.Body).ReadString('\n')
+		return nil, fmt.Errorf("请求失败，状态码: %d %s", resp.StatusCode, strings.TrimSpace(reason))
+	}
+	return resp, nil
+}
+
+// selectGoModuleVersion 选择最大的已发布版本，跳过伪版本
+// 有兼容版本时忽略 +incompatible 版本，与go命令选择 @latest 的规则一致；不检查测试版本时跳过预发布版本
+func selectGoModuleVersion(versions []string, checkTestVersion int) string {
+	type candidate struct {
+		raw     string
+		version *semver.Version
+	}
+
+	var compatible, incompatible []candidate
+	for _, raw := range versions {
+		if goPseudoVersionPattern.MatchString(raw) {
+			continue
+		}
+		parsed, err := semver.NewVersion(strings.TrimSuffix(raw, goIncompatibleSuffix))
+		if err != nil {
+			logger.GlobalLogger.Debugf("[gomod] 跳过无法解析的版本: %s", raw)
+			continue
+		}
+		if checkTestVersion != 1 && parsed.Prerelease() != "" {
+			continue
+		}
+		if strings.HasSuffix(raw, goIncompatibleSuffix) {
+			incompatible = append(incompatible, candidate{raw, parsed})
+		} else {
+			compatible = append(compatible, candidate{raw, parsed})
+		}
+	}
+
+	candidates := compatible
+	if len(candidates) == 0 {
+		candidates = incompatible
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].version.GreaterThan(candidates[j].version)
+	})
+	return candidates[0].raw
+}
+
+// goModulePath 从pkg.go.dev地址、模块代理地址或模块路径中获取模块路径
+// URL不是这些地址时（如GitHub仓库地址），使用versionExtractKey作为模块路径
+func goModulePath(url, versionExtractKey string) (string, error) {
+	url = strings.TrimSpace(url)
+	if matches := goModulePagePattern.FindStringSubmatch(url); len(matches) > 1 {
+		return strings.TrimSuffix(matches[1], "/"), nil
+	}
+	if i := strings.Index(url, "/@"); i >= 0 && strings.HasPrefix(url, "http") {
+		// 代理地址 https://proxy.golang.org/<模块>/@v/list 中，模块路径是主机名之后的部分
+		rest := url[:i]
+		if j := strings.Index(rest, "://"); j >= 0 {
+			rest = rest[j+3:]
+		}
+		if j := strings.Index(rest, "/"); j >= 0 {
+			return unescapeGoModulePath(rest[j+1:]), nil
+		}
+	}
+	if url != "" && !strings.Contains(url, "://") {
+		return strings.TrimSuffix(url, "/"), nil
+	}
+	if versionExtractKey != "" {
+		return versionExtractKey, nil
+	}
+	return "", fmt.Errorf("无法从URL中获取Go模块路径: %s", url)
+}
+
+// escapeGoModulePath 按模块代理协议转义模块路径，大写字母转换为 ! 加小写字母
+func escapeGoModulePath(path string) string {
+	var builder strings.Builder
+	for _, r := range path {
+		if unicode.IsUpper(r) {
+			builder.WriteByte('!')
+			builder.WriteRune(unicode.ToLower(r))
+		} else {
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
+
+// unescapeGoModulePath escapeGoModulePath的逆操作
+func unescapeGoModulePath(path string) string {
+	var builder strings.Builder
+	upper := false
+	for _, r := range path {
+		if r == '!' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
+
+// goProxyFromEnv 返回GOPROXY环境变量中第一个HTTP(S)代理，未设置时返回默认代理
+func goProxyFromEnv() string {
+	for _, proxy := range strings.FieldsFunc(os.Getenv("GOPROXY"), func(r rune) bool { return r == ',' || r == '|' }) {
+		if strings.HasPrefix(proxy, "http://") || strings.HasPrefix(proxy, "https://") {
+			return strings.TrimSuffix(proxy, "/")
+		}
+	}
+	return defaultGoProxy
+}
@@ -0,0 +1,195 @@
+package checkers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"aur-update-checker/internal/checkers/common"
+	"aur-update-checker/internal/logger"
+	"aur-update-checker/internal/utils"
+
+	checkerInterfaces "aur-update-checker/internal/interfaces/checkers"
+)
+
+const (
+	// hackageBaseURL Hackage的地址
+	hackageBaseURL = "https://hackage.haskell.org"
+	// hackagePreferredURL 包的首选版本信息，包括正常版本和已弃用（deprecated）的版本
+	hackagePreferredURL = hackageBaseURL + "/package/%s/preferred"
+	// hackageUploadTimeURL 版本的上传时间，响应为纯文本
+	hackageUploadTimeURL = hackageBaseURL + "/package/%s-%s/upload-time"
+	// hackageTarballURL 版本的源码包地址
+	hackageTarballURL = hackageBaseURL + "/package/%s-%s/%s-%s.tar.gz"
+)
+
+// hackageNamePattern 匹配Hackage的包页面地址，如 /package/pandoc、/package/pandoc-3.1
+var hackageNamePattern = regexp.MustCompile(`hackage\.haskell\.org/package/([A-Za-z0-9-]*?[A-Za-z][A-Za-z0-9]*)(?:-[0-9]+(?:\.[0-9]+)*)?(?:[/?#]|$)`)
+
+// HackagePreferred Hackage首选版本API的响应
+type HackagePreferred struct {
+	NormalVersions     []string `json:"normal-version"`
+	DeprecatedVersions []string `json:"deprecated-version"`
+}
+
+// HackageChecker Hackage检查器
+type HackageChecker struct {
+	*checkerInterfaces.BaseChecker
+	client *http.Client
+}
+
+// NewHackageChecker 创建Hackage检查器
+func NewHackageChecker() *HackageChecker {
+	return &HackageChecker{
+		BaseChecker: checkerInterfaces.NewBaseChecker("hackage"),
+		client:      &http.Client{},
+	}
+}
+
+// Supports 检查URL是否是Hackage的包地址
+func (c *HackageChecker) Supports(url string) bool {
+	return hackageNamePattern.MatchString(url)
+}
+
+// Priority 专用于Hackage，优先级高于通用检查器
+func (c *HackageChecker) Priority() int {
+	return 80
+}
+
+// Check 实现检查器接口，获取Haskell包的最新版本
+func (c *HackageChecker) Check(ctx context.Context, url, versionExtractKey string) (string, error) {
+	// 默认不检查测试版本
+	return c.CheckWithOption(ctx, url, versionExtractKey, 0)
+}
+
+// CheckWithOption 实现检查器接口，根据选项获取Haskell包的最新版本
+func (c *HackageChecker) CheckWithOption(ctx context.Context, url, versionExtractKey string, checkTestVersion int) (string, error) {
+	return c.CheckWithVersionRef(ctx, url, versionExtractKey, "", checkTestVersion)
+}
+
+// CheckWithVersionRef 带选项和版本引用地检查上游版本
+func (c *HackageChecker) CheckWithVersionRef(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (string, error) {
+	result, err := c.CheckWithResult(ctx, url, versionExtractKey, versionRef, checkTestVersion)
+	if err != nil {
+		return "", err
+	}
+	return result.Version, nil
+}
+
+// CheckWithResult 实现检查器接口，返回未弃用的最大版本、上传时间和源码包地址
+// Hackage的版本号遵循PVP，全部由数字组成，没有预发布版本
+func (c *HackageChecker) CheckWithResult(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (*common.UpstreamCheckResult, error) {
+	name, err := c.packageName(url, versionExtractKey)
+	if err != nil {
+		logger.GlobalLogger.Errorf("[hackage] %v", err)
+		return nil, err
+	}
+	logger.GlobalLogger.Debugf("[hackage] 开始检查Haskell包 - 名称: %s", name)
+
+	preferred, err := c.fetchPreferred(ctx, name)
+	if err != nil {
+		logger.GlobalLogger.Errorf("[hackage] 获取包版本失败: %v", err)
+		return nil, fmt.Errorf("获取包版本失败: %v", err)
+	}
+
+	selected := ""
+	for _, version := range preferred.NormalVersions {
+		if selected == "" || utils.CompareVersionStrings(version, selected) > 0 {
+			selected = version
+		}
+	}
+	if selected == "" {
+		logger.GlobalLogger.Errorf("[hackage] 包 %s 没有未弃用的版本", name)
+		return nil, fmt.Errorf("包 %s 没有未弃用的版本，已弃用%d个版本", name, len(preferred.DeprecatedVersions))
+	}
+
+	result := &common.UpstreamCheckResult{
+		Version:   c.BaseChecker.NormalizeVersionWithOption(selected, checkTestVersion),
+		TagName:   selected,
+		SourceURL: fmt.Sprintf("%s/package/%s-%s", hackageBaseURL, name, selected),
+		Assets: []common.ReleaseAsset{{
+			Name:        fmt.Sprintf("%s-%s.tar.gz", name, selected),
+			DownloadURL: fmt.Sprintf(hackageTarballURL, name, selected, name, selected),
+			Default:     true,
+		}},
+	}
+	if uploaded, err := c.fetchUploadTime(ctx, name, selected); err != nil {
+		logger.GlobalLogger.Warnf("[hackage] 获取版本 %s 的上传时间失败: %v", selected, err)
+	} else if parsed, err := time.Parse(time.UnixDate, uploaded); err == nil {
+		// Hackage使用 %c 格式输出上传时间，如 Sat Feb 24 09:43:47 UTC 2024
+		result.ReleaseDate = parsed
+	} else {
+		result.ReleaseDate = common.ParseReleaseDate(uploaded)
+	}
+
+	logger.GlobalLogger.Infof("[hackage] 包 %s 的最新版本: %s", name, result.Version)
+	return result, nil
+}
+
+// packageName 从URL中提取包名，URL不是Hackage地址时使用versionExtractKey
+func (c *HackageChecker) packageName(url, versionExtractKey string) (string, error) {
+	if matches := hackageNamePattern.FindStringSubmatch(url); len(matches) > 1 {
+		return matches[1], nil
+	}
+	if versionExtractKey != "" {
+		return versionExtractKey, nil
+	}
+	return "", fmt.Errorf("无法从URL中提取Hackage包名: %s", url)
+}
+
+// fetchPreferred 获取包的首选版本信息
+func (c *HackageChecker) fetchPreferred(ctx context.Context, name string) (*HackagePreferred, error) {
+	body, err := c.get(ctx, fmt.Sprintf(hackagePreferredURL, name), "application/json")
+	if err != nil {
+		return nil, err
+	}
+
+	var preferred HackagePreferred
+	if err := json.Unmarshal(body, &preferred); err != nil {
+		return nil, fmt.Errorf("解析响应失败: %v", err)
+	}
+	return &preferred, nil
+}
+
+// fetchUploadTime 获取版本的上传时间
+func (c *HackageChecker) fetchUploadTime(ctx context.Context, name, version string) (string, error) {
+	body, err := c.get(ctx, fmt.Sprintf(hackageUploadTimeURL, name, version), "text/plain")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+// get 发送GET请求并返回响应体
+func (c *HackageChecker) get(ctx context.Context, url, accept string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %v", err)
+	}
+	req.Header.Set("User-Agent", "aur-update-checker")
+	req.Header.Set("Accept", accept)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("请求失败: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("地址不存在: %s", url)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("请求失败，状态码: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("读取响应体失败: %v", err)
+	}
+	return body, nil
+}
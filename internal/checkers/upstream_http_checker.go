@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"regexp"
 	"strings"
+	"time"
 
 	checkerInterfaces "aur-update-checker/internal/interfaces/checkers"
 	version "aur-update-checker/internal/checkers/version"
@@ -36,40 +37,99 @@ func (c *HttpChecker) Check(ctx context.Context, url, versionExtractKey string)
 
 // CheckWithOption 实现检查器接口，根据选项检查上游版本
 func (c *HttpChecker) CheckWithOption(ctx context.Context, url, versionExtractKey string, checkTestVersion int) (string, error) {
+	result, err := c.CheckWithResult(ctx, url, versionExtractKey, "", checkTestVersion)
+	if err != nil {
+		return "", err
+	}
+	return result.Version, nil
+}
+
+// CheckWithResult 实现检查器接口，发布时间使用页面的Last-Modified响应头
+func (c *HttpChecker) CheckWithResult(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (*common.UpstreamCheckResult, error) {
 	logger.GlobalLogger.Infof("[HTTP检查器] 开始检查上游版本 - URL: %s, 提取键: %s, 检查测试版本: %d", url, versionExtractKey, checkTestVersion)
 
 	if versionExtractKey == "" {
 		logger.GlobalLogger.Errorf("[HTTP检查器] versionExtractKey为空")
-		return "", fmt.Errorf("HTTP检查器需要提供versionExtractKey来定位版本信息")
+		return nil, fmt.Errorf("HTTP检查器需要提供versionExtractKey来定位版本信息")
 	}
 
-	// 获取页面内容
+	// 获取页面内容，选择器模式需要原始HTML，不做单页应用的数据提取
 	logger.GlobalLogger.Debugf("[HTTP检查器] 获取页面内容: %s", url)
-	content, err := c.fetchContent(ctx, url)
+	selectorMode := common.IsSelectorKey(versionExtractKey)
+	fetch := c.fetchContent
+	if selectorMode {
+		fetch = c.fetchPage
+	}
+	content, lastModified, err := fetch(ctx, url)
 	if err != nil {
 		logger.GlobalLogger.Errorf("[HTTP检查器] 获取页面内容失败: %v", err)
-		return "", fmt.Errorf("获取页面内容失败: %v", err)
+		return nil, fmt.Errorf("获取页面内容失败: %v", err)
 	}
 	logger.GlobalLogger.Debugf("[HTTP检查器] 成功获取页面内容，长度: %d", len(content))
 
 	// 提取版本
 	logger.GlobalLogger.Debugf("[HTTP检查器] 开始提取版本，提取键: %s", versionExtractKey)
-	version, err := c.extractVersion(content, versionExtractKey)
+	var version string
+	if selectorMode {
+		version, err = c.extractSelectorVersion(content, versionExtractKey, checkTestVersion)
+	} else {
+		version, err = c.extractVersion(content, versionExtractKey)
+	}
 	if err != nil {
 		logger.GlobalLogger.Errorf("[HTTP检查器] 从页面内容提取版本失败: %v", err)
-		return "", fmt.Errorf("从页面内容提取版本失败: %v", err)
+		return nil, fmt.Errorf("从页面内容提取版本失败: %v", err)
 	}
 	logger.GlobalLogger.Infof("[HTTP检查器] 成功提取版本: %s", version)
 
 	// 规范化版本号，移除平台特定信息
 	normalizedVersion := c.BaseChecker.NormalizeVersionWithOption(version, checkTestVersion)
 	logger.GlobalLogger.Infof("[HTTP检查器] 版本规范化完成，最终版本: %s", normalizedVersion)
-	return normalizedVersion, nil
+
+	result := common.NewVersionResult(normalizedVersion, url)
+	result.ReleaseDate = lastModified
+	return result, nil
 }
 
-// fetchContent 获取页面内容
+// fetchContent 获取页面内容，单页应用时尝试从HTML中提取API数据
+// 同时返回Last-Modified响应头中的时间，服务器未提供时为零值
 // 注意：这里简化了实现，实际应该使用像playwright或chromedp这样的库来渲染JS页面
-func (c *HttpChecker) fetchContent(ctx context.Context, url string) (string, error) {
+func (c *HttpChecker) fetchContent(ctx context.Context, url string) (string, time.Time, error) {
+	content, lastModified, err := c.fetchPage(ctx, url)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	// 检查是否是单页应用，并尝试从初始HTML中提取API数据
+	isSPA := strings.Contains(content, "<div id=\"app\"") || 
+	         strings.Contains(content, "angular") || 
+	         strings.Contains(content, "react") || 
+	         strings.Contains(content, "vue")
+
+	logger.GlobalLogger.Debugf("[HTTP检查器] 检测是否为单页应用: %v", isSPA)
+
+	if isSPA {
+		logger.GlobalLogger.Infof("[HTTP检查器] 检测到可能是单页应用，尝试从HTML中提取数据")
+		// 尝试从HTML中提取可能的API数据或版本信息
+		if apiData, found := c.extractAPIDataFromHTML(content); found {
+			logger.GlobalLogger.Infof("[HTTP检查器] 从HTML中提取到API数据")
+			return apiData, lastModified, nil
+		}
+		logger.GlobalLogger.Warnf("[HTTP检查器] 未从HTML中提取到API数据，返回原始HTML")
+	}
+
+	// 添加调试信息，检查内容中是否包含我们需要的键
+	if strings.Contains(content, "Linux") {
+		logger.GlobalLogger.Debugf("[HTTP检查器] 内容中包含'Linux'")
+	}
+	if strings.Contains(content, "信创") {
+		logger.GlobalLogger.Debugf("[HTTP检查器] 内容中包含'信创'")
+	}
+
+	return content, lastModified, nil
+}
+
+// fetchPage 获取页面的原始HTML和Last-Modified响应头中的时间
+func (c *HttpChecker) fetchPage(ctx context.Context, url string) (string, time.Time, error) {
 	logger.GlobalLogger.Debugf("[HTTP检查器] 创建HTTP请求: %s", url)
 
 	// 处理单页应用URL，去掉#后面的部分，因为服务器只返回基础HTML
@@ -83,7 +143,7 @@ func (c *HttpChecker) fetchContent(ctx context.Context, url string) (string, err
 	req, err := http.NewRequestWithContext(ctx, "GET", baseURL, nil)
 	if err != nil {
 		logger.GlobalLogger.Errorf("[HTTP检查器] 创建请求失败: %v", err)
-		return "", fmt.Errorf("创建请求失败: %v", err)
+		return "", time.Time{}, fmt.Errorf("创建请求失败: %v", err)
 	}
 
 	// 设置User-Agent，模拟浏览器
@@ -96,53 +156,29 @@ func (c *HttpChecker) fetchContent(ctx context.Context, url string) (string, err
 	resp, err := c.client.Do(req)
 	if err != nil {
 		logger.GlobalLogger.Errorf("[HTTP检查器] 请求失败: %v", err)
-		return "", fmt.Errorf("请求失败: %v", err)
+		return "", time.Time{}, fmt.Errorf("请求失败: %v", err)
 	}
 	defer resp.Body.Close()
 	logger.GlobalLogger.Debugf("[HTTP检查器] 收到HTTP响应，状态码: %d", resp.StatusCode)
 
 	if resp.StatusCode != http.StatusOK {
 		logger.GlobalLogger.Errorf("[HTTP检查器] 请求失败，状态码: %d", resp.StatusCode)
-		return "", fmt.Errorf("请求失败，状态码: %d", resp.StatusCode)
+		return "", time.Time{}, fmt.Errorf("请求失败，状态码: %d", resp.StatusCode)
 	}
 
+	// Last-Modified无法解析时为零值
+	lastModified, _ := http.ParseTime(resp.Header.Get("Last-Modified"))
+
 	logger.GlobalLogger.Debugf("[HTTP检查器] 读取响应体")
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		logger.GlobalLogger.Errorf("[HTTP检查器] 读取响应体失败: %v", err)
-		return "", fmt.Errorf("读取响应体失败: %v", err)
+		return "", time.Time{}, fmt.Errorf("读取响应体失败: %v", err)
 	}
 
 	content := string(body)
 	logger.GlobalLogger.Debugf("[HTTP检查器] 成功获取页面内容，长度: %d", len(content))
-
-	// 检查是否是单页应用，并尝试从初始HTML中提取API数据
-	isSPA := strings.Contains(content, "<div id=\"app\"") || 
-	         strings.Contains(content, "angular") || 
-	         strings.Contains(content, "react") || 
-	         strings.Contains(content, "vue")
-
-	logger.GlobalLogger.Debugf("[HTTP检查器] 检测是否为单页应用: %v", isSPA)
-
-	if isSPA {
-		logger.GlobalLogger.Infof("[HTTP检查器] 检测到可能是单页应用，尝试从HTML中提取数据")
-		// 尝试从HTML中提取可能的API数据或版本信息
-		if apiData, found := c.extractAPIDataFromHTML(content); found {
-			logger.GlobalLogger.Infof("[HTTP检查器] 从HTML中提取到API数据")
-			return apiData, nil
-		}
-		logger.GlobalLogger.Warnf("[HTTP检查器] 未从HTML中提取到API数据，返回原始HTML")
-	}
-
-	// 添加调试信息，检查内容中是否包含我们需要的键
-	if strings.Contains(content, "Linux") {
-		logger.GlobalLogger.Debugf("[HTTP检查器] 内容中包含'Linux'")
-	}
-	if strings.Contains(content, "信创") {
-		logger.GlobalLogger.Debugf("[HTTP检查器] 内容中包含'信创'")
-	}
-
-	return content, nil
+	return content, lastModified, nil
 }
 
 // extractVersion 从页面内容中提取版本
@@ -164,6 +200,23 @@ func (c *HttpChecker) extractVersion(content, versionExtractKey string) (string,
 	return latestVersion, nil
 }
 
+// extractSelectorVersion 使用 css: 或 xpath: 选择器从页面中选取节点，返回其中最新的版本，不检查测试版本时跳过测试版本
+func (c *HttpChecker) extractSelectorVersion(content, versionExtractKey string, checkTestVersion int) (string, error) {
+	versions, err := common.ExtractSelectorVersions(content, versionExtractKey)
+	if err != nil {
+		logger.GlobalLogger.Warnf("[HTTP检查器] %v", err)
+		return "", err
+	}
+	logger.GlobalLogger.Debugf("[HTTP检查器] 选择器提取到 %d 个版本: %v", len(versions), versions)
+
+	latestVersion, err := common.LatestSelectorVersion(versions, checkTestVersion)
+	if err != nil {
+		logger.GlobalLogger.Warnf("[HTTP检查器] %v", err)
+		return "", err
+	}
+	return latestVersion, nil
+}
+
 // findAllVersions 查找所有匹配版本提取键的内容
 func (c *HttpChecker) findAllVersions(content, key string) []string {
 	var versions []string
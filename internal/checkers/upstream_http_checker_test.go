@@ -0,0 +1,58 @@
+package checkers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHttpCheckerSelectorVersions(t *testing.T) {
+	pages := map[string]string{
+		"/releases": `<html><body>
+			<div class="release"><h2>Version 2.0.0-beta.1</h2></div>
+			<div class="release"><h2>Version 1.9.2</h2></div>
+			<div class="release"><h2>Version 1.10.0</h2></div>
+			<a href="/files/app_2.0.0-rc1_amd64.deb">rc</a>
+			<a href="/files/app_1.10.0_amd64.deb">stable</a>
+		</body></html>`,
+		"/beta": `<html><body><div class="release"><h2>Version 3.0.0-alpha.2</h2></div></body></html>`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, ok := pages[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(page))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name             string
+		path             string
+		key              string
+		checkTestVersion int
+		want             string
+		wantErr          bool
+	}{
+		{"CSS跳过测试版本", "/releases", "css:div.release > h2", 0, "1.10.0", false},
+		{"CSS包括测试版本", "/releases", "css:div.release > h2", 1, "2.0.0-beta.1", false},
+		{"XPath属性跳过测试版本", "/releases", "xpath://a[contains(@href,'.deb')]/@href", 0, "1.10.0", false},
+		{"只有测试版本", "/beta", "css:div.release > h2", 0, "", true},
+		{"只有测试版本时检查测试版本", "/beta", "css:div.release > h2", 1, "3.0.0-alpha.2", false},
+	}
+
+	checker := NewHttpChecker()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := checker.CheckWithOption(context.Background(), server.URL+tt.path, tt.key, tt.checkTestVersion)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckWithOption(%q) 错误 = %v，期望返回错误: %v", tt.key, err, tt.wantErr)
+			}
+			if version != tt.want {
+				t.Errorf("CheckWithOption(%q) = %q，期望 %q", tt.key, version, tt.want)
+			}
+		})
+	}
+}
@@ -1,18 +1,39 @@
 package checkers
 
 import (
+	"aur-update-checker/internal/checkers/common"
 	"aur-update-checker/internal/logger"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	neturl "net/url"
+	"path"
 	"strings"
 
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
 	checkerInterfaces "aur-update-checker/internal/interfaces/checkers"
 )
 
-// JsonChecker JSON检查器
+const (
+	// OptionFormat 软件包检查器选项：文档格式，json、yaml 或 toml，默认根据URL扩展名和Content-Type判断
+	OptionFormat = "format"
+
+	// DocumentFormatJSON JSON文档
+	DocumentFormatJSON = "json"
+	// DocumentFormatYAML YAML文档
+	DocumentFormatYAML = "yaml"
+	// DocumentFormatTOML TOML文档
+	DocumentFormatTOML = "toml"
+
+	// maxJSONDocumentSize 文档的最大读取长度
+	maxJSONDocumentSize = 20 << 20
+)
+
+// JsonChecker JSON检查器，支持JSON、YAML和TOML文档
 type JsonChecker struct {
 	*checkerInterfaces.BaseChecker
 	client *http.Client
@@ -27,6 +48,7 @@ func NewJsonChecker() *JsonChecker {
 }
 
 // Check 实现检查器接口，从JSON文件中提取版本
+// versionExtractKey 是JSONPath/jq风格的路径表达式，如 releases[?(@.channel=='stable')].version | max_version
 func (c *JsonChecker) Check(ctx context.Context, url, versionExtractKey string) (string, error) {
 	// 默认不检查测试版本
 	return c.CheckWithOption(ctx, url, versionExtractKey, 0)
@@ -45,18 +67,22 @@ func (c *JsonChecker) CheckWithVersionRef(ctx context.Context, url, versionExtra
 		return "", fmt.Errorf("JSON检查器需要提供versionExtractKey来定位版本信息")
 	}
 
-	// 获取JSON文件内容
-	jsonData, err := c.fetchJSON(ctx, url)
+	// 编译路径表达式，兼容旧的 a.b.c 格式
+	documentPath, err := common.CompileDocumentPath(versionExtractKey)
+	if err != nil {
+		logger.GlobalLogger.Errorf("[json] 解析路径表达式失败: %v", err)
+		return "", fmt.Errorf("解析路径表达式失败: %v", err)
+	}
+
+	// 获取文档内容
+	document, err := c.fetchDocument(ctx, url)
 	if err != nil {
 		logger.GlobalLogger.Errorf("[json] 获取JSON文件失败: %v", err)
 		return "", fmt.Errorf("获取JSON文件失败: %v", err)
 	}
 
-	// 解析JSON路径
-	paths := strings.Split(versionExtractKey, ".")
-
-	// 从JSON数据中提取版本
-	version, err := c.extractVersionFromJSON(jsonData, paths)
+	// 从文档中提取版本
+	version, err := c.extractVersionFromDocument(document, documentPath, checkTestVersion)
 	if err != nil {
 		logger.GlobalLogger.Errorf("[json] 从JSON中提取版本失败: %v", err)
 		return "", fmt.Errorf("从JSON中提取版本失败: %v", err)
@@ -74,8 +100,8 @@ func (c *JsonChecker) CheckWithVersionRef(ctx context.Context, url, versionExtra
 	return normalizedVersion, nil
 }
 
-// fetchJSON 获取JSON文件内容
-func (c *JsonChecker) fetchJSON(ctx context.Context, url string) (map[string]interface{}, error) {
+// fetchDocument 获取文档内容，按 format 选项、URL扩展名或Content-Type解码JSON、YAML或TOML
+func (c *JsonChecker) fetchDocument(ctx context.Context, url string) (interface{}, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		logger.GlobalLogger.Errorf("[json] 创建请求失败: %v", err)
@@ -94,61 +120,123 @@ func (c *JsonChecker) fetchJSON(ctx context.Context, url string) (map[string]int
 		return nil, fmt.Errorf("请求失败，状态码: %d", resp.StatusCode)
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxJSONDocumentSize))
 	if err != nil {
 		logger.GlobalLogger.Errorf("[json] 读取响应体失败: %v", err)
 		return nil, fmt.Errorf("读取响应体失败: %v", err)
 	}
 
-	var result map[string]interface{}
-	if err := json.Unmarshal(body, &result); err != nil {
-		logger.GlobalLogger.Errorf("[json] 解析JSON失败: %v", err)
-		return nil, fmt.Errorf("解析JSON失败: %v", err)
+	format := strings.ToLower(common.CheckOptionsFromContext(ctx).Get(OptionFormat, ""))
+	if format == "" {
+		format = detectDocumentFormat(url, resp.Header.Get("Content-Type"))
 	}
+	logger.GlobalLogger.Debugf("[json] 按 %s 格式解析文档", documentFormatName(format))
 
-	return result, nil
+	document, err := decodeDocument(body, format)
+	if err != nil {
+		logger.GlobalLogger.Errorf("[json] 解析文档失败: %v", err)
+		return nil, err
+	}
+	return document, nil
 }
 
-// extractVersionFromJSON 从JSON数据中提取版本
-func (c *JsonChecker) extractVersionFromJSON(data map[string]interface{}, paths []string) (string, error) {
-	var current interface{} = data
-
-	// 遍历路径
-	for i, path := range paths {
-		switch v := current.(type) {
-		case map[string]interface{}:
-			if next, ok := v[path]; ok {
-				current = next
-			} else {
-				logger.GlobalLogger.Errorf("[json] 路径 '%s' 不存在于JSON中", strings.Join(paths[:i+1], "."))
-				return "", fmt.Errorf("路径 '%s' 不存在于JSON中", strings.Join(paths[:i+1], "."))
-			}
-		default:
-			// 如果不是最后一个路径元素，但当前值不是map，则无法继续
-			if i < len(paths)-1 {
-				logger.GlobalLogger.Errorf("[json] 路径 '%s' 不是对象，无法继续", strings.Join(paths[:i+1], "."))
-				return "", fmt.Errorf("路径 '%s' 不是对象，无法继续", strings.Join(paths[:i+1], "."))
-			}
+// detectDocumentFormat 根据URL扩展名和Content-Type判断文档格式，无法判断时返回空字符串
+func detectDocumentFormat(url, contentType string) string {
+	if parsed, err := neturl.Parse(url); err == nil {
+		switch strings.ToLower(path.Ext(parsed.Path)) {
+		case ".yaml", ".yml":
+			return DocumentFormatYAML
+		case ".toml":
+			return DocumentFormatTOML
+		case ".json":
+			return DocumentFormatJSON
 		}
 	}
 
-	// 处理最终值
-	switch v := current.(type) {
-	case string:
-		return v, nil
-	case float64:
-		// JSON数字默认解析为float64
-		return fmt.Sprintf("%v", v), nil
-	case map[string]interface{}, []interface{}:
-		// 如果是对象或数组，需要进一步处理
-		// 这里简化处理，将整个结构转换为JSON字符串
-		jsonBytes, err := json.Marshal(v)
-		if err != nil {
-			logger.GlobalLogger.Errorf("[json] 将复杂结构转换为字符串失败: %v", err)
-			return "", fmt.Errorf("将复杂结构转换为字符串失败: %v", err)
+	contentType = strings.ToLower(contentType)
+	switch {
+	case strings.Contains(contentType, "yaml"):
+		return DocumentFormatYAML
+	case strings.Contains(contentType, "toml"):
+		return DocumentFormatTOML
+	case strings.Contains(contentType, "json"):
+		return DocumentFormatJSON
+	}
+	return ""
+}
+
+// documentFormatName 返回格式名称，用于日志
+func documentFormatName(format string) string {
+	if format == "" {
+		return "JSON（失败时尝试YAML）"
+	}
+	return strings.ToUpper(format)
+}
+
+// decodeDocument 按格式解码文档，未指定格式时先按JSON解析，失败后按YAML解析
+func decodeDocument(body []byte, format string) (interface{}, error) {
+	switch format {
+	case DocumentFormatJSON:
+		var result interface{}
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, fmt.Errorf("解析JSON失败: %v", err)
+		}
+		return result, nil
+	case DocumentFormatYAML:
+		var result interface{}
+		if err := yaml.Unmarshal(body, &result); err != nil {
+			return nil, fmt.Errorf("解析YAML失败: %v", err)
+		}
+		return common.NormalizeDocument(result), nil
+	case DocumentFormatTOML:
+		var result map[string]interface{}
+		if err := toml.Unmarshal(body, &result); err != nil {
+			return nil, fmt.Errorf("解析TOML失败: %v", err)
 		}
-		return string(jsonBytes), nil
-	default:
-		return fmt.Sprintf("%v", v), nil
+		return common.NormalizeDocument(result), nil
+	case "":
+		result, jsonErr := decodeDocument(body, DocumentFormatJSON)
+		if jsonErr == nil {
+			return result, nil
+		}
+		// YAML是JSON的超集，普通文本也能解析为字符串，只接受对象和数组
+		result, err := decodeDocument(body, DocumentFormatYAML)
+		if err == nil {
+			switch result.(type) {
+			case map[string]interface{}, []interface{}:
+				return result, nil
+			}
+		}
+		return nil, jsonErr
+	}
+	return nil, fmt.Errorf("不支持的文档格式: %s，可选 json、yaml、toml", format)
+}
+
+// extractVersionFromDocument 对文档求值路径表达式，有多个结果且表达式没有使用 max_version 等归约时取第一个
+func (c *JsonChecker) extractVersionFromDocument(document interface{}, documentPath *common.DocumentPath, checkTestVersion int) (string, error) {
+	values, err := documentPath.Evaluate(document, checkTestVersion == 1)
+	if err != nil {
+		return "", err
+	}
+	if len(values) == 0 {
+		return "", fmt.Errorf("路径 '%s' 不存在于JSON中", documentPath)
+	}
+	if len(values) > 1 {
+		logger.GlobalLogger.Debugf("[json] 路径 '%s' 匹配到 %d 个值，使用第一个，可在表达式末尾添加 | max_version 选择最新版本", documentPath, len(values))
+	}
+
+	version := common.DocumentValueString(values[0])
+	if version == "" {
+		return "", fmt.Errorf("路径 '%s' 的值为空", documentPath)
+	}
+	return version, nil
+}
+
+// CheckWithResult 实现检查器接口，该检查器只能获取版本号，结果中不包含发布时间
+func (c *JsonChecker) CheckWithResult(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (*common.UpstreamCheckResult, error) {
+	version, err := c.CheckWithVersionRef(ctx, url, versionExtractKey, versionRef, checkTestVersion)
+	if err != nil {
+		return nil, err
 	}
+	return common.NewVersionResult(version, url), nil
 }
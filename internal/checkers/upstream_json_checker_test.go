@@ -0,0 +1,79 @@
+package checkers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"aur-update-checker/internal/checkers/common"
+)
+
+func TestJsonCheckerDocumentFormats(t *testing.T) {
+	documents := map[string]struct {
+		contentType string
+		body        string
+	}{
+		"/release.json":         {"application/json", `{"info": {"version": "1.2.3"}, "releases": [{"version": "1.0"}, {"version": "1.10"}]}`},
+		"/release.yaml":         {"", "info:\n  version: 2.3.4\nreleases:\n  - version: 0.9.0\n  - version: 0.10.0\n"},
+		"/release":              {"application/toml", "[info]\nversion = \"3.4.5\"\n\n[[releases]]\nversion = \"2.0\"\n\n[[releases]]\nversion = \"2.1\"\n"},
+		"/plain":                {"text/plain", "version: 4.5.6\n"},
+		"/duplicate.toml":       {"", "[info]\nversion = \"1.0\"\nversion = \"1.1\"\n"},
+		"/duplicate-table.toml": {"", "[info]\nversion = \"1.0\"\n[info]\nname = \"x\"\n"},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		document, ok := documents[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		if document.contentType != "" {
+			w.Header().Set("Content-Type", document.contentType)
+		}
+		w.Write([]byte(document.body))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		path    string
+		key     string
+		options string
+		want    string
+		wantErr bool
+	}{
+		{"JSON点分隔路径", "/release.json", "info.version", "", "1.2.3", false},
+		{"JSON max_version", "/release.json", "releases[*].version | max_version", "", "1.10", false},
+		{"按扩展名解析YAML", "/release.yaml", "info.version", "", "2.3.4", false},
+		{"YAML max_version", "/release.yaml", "$.releases[*].version | max_version", "", "0.10.0", false},
+		{"按Content-Type解析TOML", "/release", "info.version", "", "3.4.5", false},
+		{"TOML表数组", "/release", "releases | last | .version", "", "2.1", false},
+		{"JSON失败时按YAML解析", "/plain", "version", "", "4.5.6", false},
+		{"format选项", "/plain", "version", "format=yaml", "4.5.6", false},
+		{"format选项与内容不符", "/release.json", "info.version", "format=toml", "", true},
+		{"不支持的format选项", "/release.json", "info.version", "format=xml", "", true},
+		{"TOML重复的键", "/duplicate.toml", "info.version", "", "", true},
+		{"TOML重复的表", "/duplicate-table.toml", "info.version", "", "", true},
+		{"路径不存在", "/release.json", "info.missing", "", "", true},
+	}
+
+	checker := NewJsonChecker()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := common.WithCheckOptions(context.Background(), common.ParseCheckOptions(tt.options))
+			got, err := checker.Check(ctx, server.URL+tt.path, tt.key)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Check(%s, %q) = %q，期望返回错误", tt.path, tt.key, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Check(%s, %q) 返回错误: %v", tt.path, tt.key, err)
+			}
+			if got != tt.want {
+				t.Errorf("Check(%s, %q) = %q，期望 %q", tt.path, tt.key, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package checkers
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"aur-update-checker/internal/checkers/common"
+	versionProcessor "aur-update-checker/internal/checkers/version"
+	"aur-update-checker/internal/logger"
+
+	checkerInterfaces "aur-update-checker/internal/interfaces/checkers"
+)
+
+const (
+	// defaultMavenRepository 默认的Maven仓库，即Maven Central
+	defaultMavenRepository = "https://repo1.maven.org/maven2"
+	// mavenMetadataFile 构件目录下的版本元数据文件
+	mavenMetadataFile = "maven-metadata.xml"
+	// mavenLastUpdatedLayout maven-metadata.xml中lastUpdated的时间格式
+	mavenLastUpdatedLayout = "20060102150405"
+)
+
+var (
+	// mavenArtifactPagePattern 匹配Maven Central、mvnrepository等网站的构件页面地址
+	mavenArtifactPagePattern = regexp.MustCompile(`^https?://(?:central\.sonatype\.com|mvnrepository\.com|search\.maven\.org)/artifact/([^/?#]+)/([^/?#]+)`)
+	// mavenCoordinatesPattern 匹配 groupId:artifactId 格式的构件坐标
+	mavenCoordinatesPattern = regexp.MustCompile(`^([A-Za-z0-9_.-]+):([A-Za-z0-9_.-]+)$`)
+	// mavenPrereleasePattern 匹配Maven的测试版本限定符，如 1.0-SNAPSHOT、2.0.0-M1、5.0.0.RC2、3.0.0.CR1、21-ea
+	// 限定符前后必须是分隔符、数字或字符串边界，Final、RELEASE、GA 等限定符不受影响
+	mavenPrereleasePattern = regexp.MustCompile(`(?i)(?:^|[^a-z])(?:snapshot|alpha|beta|rc|cr|ea|preview|m\d)\d*(?:[^a-z]|$)`)
+	// mavenRepositoryPathPattern 匹配Maven Central中的构件目录地址，如 https://repo1.maven.org/maven2/com/google/guava/guava/
+	mavenRepositoryPathPattern = regexp.MustCompile(`^(https?://repo1\.maven\.org/maven2)/([^?#]+)`)
+)
+
+// MavenMetadata maven-metadata.xml构件版本元数据
+type MavenMetadata struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Versioning struct {
+		Latest      string   `xml:"latest"`
+		Release     string   `xml:"release"`
+		Versions    []string `xml:"versions>version"`
+		LastUpdated string   `xml:"lastUpdated"`
+	} `xml:"versioning"`
+}
+
+// MavenChecker Maven仓库检查器
+type MavenChecker struct {
+	*checkerInterfaces.BaseChecker
+	client *http.Client
+}
+
+// NewMavenChecker 创建Maven仓库检查器
+func NewMavenChecker() *MavenChecker {
+	return &MavenChecker{
+		BaseChecker: checkerInterfaces.NewBaseChecker("maven"),
+		client:      &http.Client{},
+	}
+}
+
+// Supports 检查URL是否是Maven构件页面、Maven Central中的构件目录或maven-metadata.xml地址
+func (c *MavenChecker) Supports(url string) bool {
+	if mavenArtifactPagePattern.MatchString(url) || strings.HasSuffix(url, "/"+mavenMetadataFile) {
+		return true
+	}
+	_, _, _, ok := mavenRepositoryPathCoordinates(url)
+	return ok
+}
+
+// Priority 专用于Maven仓库，优先级高于通用检查器
+func (c *MavenChecker) Priority() int {
+	return 80
+}
+
+// Check 实现检查器接口，获取Maven构件的最新版本
+func (c *MavenChecker) Check(ctx context.Context, url, versionExtractKey string) (string, error) {
+	// 默认不检查测试版本
+	return c.CheckWithOption(ctx, url, versionExtractKey, 0)
+}
+
+// CheckWithOption 实现检查器接口，根据选项获取Maven构件的最新版本
+func (c *MavenChecker) CheckWithOption(ctx context.Context, url, versionExtractKey string, checkTestVersion int) (string, error) {
+	return c.CheckWithVersionRef(ctx, url, versionExtractKey, "", checkTestVersion)
+}
+
+// CheckWithVersionRef 带选项和版本引用地检查上游版本
+func (c *MavenChecker) CheckWithVersionRef(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (string, error) {
+	result, err := c.CheckWithResult(ctx, url, versionExtractKey, versionRef, checkTestVersion)
+	if err != nil {
+		return "", err
+	}
+	return result.Version, nil
+}
+
+// CheckWithResult 实现检查器接口，从maven-metadata.xml中选出最大的版本
+// 不检查测试版本时跳过SNAPSHOT、里程碑（M1）以及alpha、beta、RC等测试版本
+func (c *MavenChecker) CheckWithResult(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (*common.UpstreamCheckResult, error) {
+	metadataURL, err := mavenMetadataURL(url, versionExtractKey)
+	if err != nil {
+		logger.GlobalLogger.Errorf("[maven] %v", err)
+		return nil, err
+	}
+	logger.GlobalLogger.Debugf("[maven] 开始检查Maven构件 - 元数据: %s", metadataURL)
+
+	metadata, err := c.fetchMetadata(ctx, metadataURL)
+	if err != nil {
+		logger.GlobalLogger.Errorf("[maven] 获取构件元数据失败: %v", err)
+		return nil, fmt.Errorf("获取构件元数据失败: %v", err)
+	}
+
+	selected := c.selectVersion(metadata, checkTestVersion)
+	if selected == "" {
+		logger.GlobalLogger.Errorf("[maven] 构件 %s:%s 没有符合条件的版本", metadata.GroupID, metadata.ArtifactID)
+		return nil, fmt.Errorf("构件 %s:%s 在%d个版本中没有符合条件的版本", metadata.GroupID, metadata.ArtifactID, len(metadata.Versioning.Versions))
+	}
+
+	artifactURL := strings.TrimSuffix(metadataURL, mavenMetadataFile)
+	result := &common.UpstreamCheckResult{
+		Version:      c.BaseChecker.NormalizeVersionWithOption(selected, checkTestVersion),
+		TagName:      selected,
+		IsPrerelease: !isStableMavenVersion(selected),
+		SourceURL:    artifactURL + selected + "/",
+	}
+	// lastUpdated是元数据的更新时间，只有选中的是最新发布的版本时才能作为发布时间
+	if selected == metadata.Versioning.Release || selected == metadata.Versioning.Latest {
+		if updated, err := time.Parse(mavenLastUpdatedLayout, metadata.Versioning.LastUpdated); err == nil {
+			result.ReleaseDate = updated
+		}
+	}
+
+	logger.GlobalLogger.Infof("[maven] 构件 %s:%s 的最新版本: %s", metadata.GroupID, metadata.ArtifactID, result.Version)
+	return result, nil
+}
+
+// fetchMetadata 下载并解析maven-metadata.xml
+func (c *MavenChecker) fetchMetadata(ctx context.Context, metadataURL string) (*MavenMetadata, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", metadataURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %v", err)
+	}
+	req.Header.Set("User-Agent", "aur-update-checker")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("请求失败: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("请求失败，状态码: %d", resp.StatusCode)
+	}
+
+	var metadata MavenMetadata
+	if err := xml.NewDecoder(resp.Body).Decode(&metadata); err != nil {
+		return nil, fmt.Errorf("解析maven-metadata.xml失败: %v", err)
+	}
+	return &metadata, nil
+}
+
+// selectVersion 选择最大的版本，versions为空时使用release
+func (c *MavenChecker) selectVersion(metadata *MavenMetadata, checkTestVersion int) string {
+	comparator := versionProcessor.NewVersionComparator()
+
+	versions := metadata.Versioning.Versions
+	if len(versions) == 0 && metadata.Versioning.Release != "" {
+		versions = []string{metadata.Versioning.Release}
+	}
+
+	selected := ""
+	for _, version := range versions {
+		version = strings.TrimSpace(version)
+		if version == "" || (checkTestVersion != 1 && !isStableMavenVersion(version)) {
+			continue
+		}
+		if selected == "" || comparator.CompareVersions(version, selected) > 0 {
+			selected = version
+		}
+	}
+	return selected
+}
+
+// isStableMavenVersion 检查是否为稳定版本，带有SNAPSHOT、里程碑（M1）、alpha、beta、RC、CR、EA、preview限定符的不是稳定版本
+func isStableMavenVersion(version string) bool {
+	return !mavenPrereleasePattern.MatchString(version)
+}
+
+// mavenMetadataURL 根据URL和构件坐标返回maven-metadata.xml地址
+// URL可以是maven-metadata.xml地址、Maven Central等网站的构件页面、Maven Central中的构件目录，
+// 或Maven仓库根地址（配合版本提取关键字中的 groupId:artifactId），也可以直接在URL中填写 groupId:artifactId，此时使用Maven Central
+func mavenMetadataURL(url, versionExtractKey string) (string, error) {
+	url = strings.TrimSpace(url)
+	if strings.HasSuffix(url, "/"+mavenMetadataFile) {
+		return url, nil
+	}
+
+	repository := defaultMavenRepository
+	var groupID, artifactID string
+	if matches := mavenArtifactPagePattern.FindStringSubmatch(url); len(matches) > 2 {
+		groupID, artifactID = matches[1], matches[2]
+	} else if matches := mavenCoordinatesPattern.FindStringSubmatch(url); len(matches) > 2 {
+		groupID, artifactID = matches[1], matches[2]
+	} else if matches := mavenCoordinatesPattern.FindStringSubmatch(strings.TrimSpace(versionExtractKey)); len(matches) > 2 {
+		groupID, artifactID = matches[1], matches[2]
+		if root, _, _, ok := mavenRepositoryPathCoordinates(url); ok {
+			// Maven Central中的构件目录以 /maven2 作为仓库根地址
+			repository = root
+		} else if url != "" {
+			repository = strings.TrimSuffix(url, "/")
+		}
+	} else if root, group, artifact, ok := mavenRepositoryPathCoordinates(url); ok {
+		repository, groupID, artifactID = root, group, artifact
+	} else {
+		return "", fmt.Errorf("未指定Maven构件坐标，请在版本提取关键字中填写 groupId:artifactId")
+	}
+
+	return fmt.Sprintf("%s/%s/%s/%s", repository, strings.ReplaceAll(groupID, ".", "/"), artifactID, mavenMetadataFile), nil
+}
+
+// mavenRepositoryPathCoordinates 从Maven Central中的构件目录地址解析仓库根地址和构件坐标
+// /maven2/ 之后的最后一段是artifactId，之前的各段是groupId；最后一段以数字开头时视为版本目录并忽略
+func mavenRepositoryPathCoordinates(url string) (repository, groupID, artifactID string, ok bool) {
+	matches := mavenRepositoryPathPattern.FindStringSubmatch(strings.TrimSpace(url))
+	if matches == nil {
+		return "", "", "", false
+	}
+	segments := strings.Split(strings.Trim(matches[2], "/"), "/")
+	if len(segments) > 2 && segments[len(segments)-1] != "" && segments[len(segments)-1][0] >= '0' && segments[len(segments)-1][0] <= '9' {
+		segments = segments[:len(segments)-1]
+	}
+	if len(segments) < 2 {
+		return "", "", "", false
+	}
+	for _, segment := range segments {
+		if segment == "" || segment == "." || segment == ".." {
+			return "", "", "", false
+		}
+	}
+	return matches[1], strings.Join(segments[:len(segments)-1], "."), segments[len(segments)-1], true
+}
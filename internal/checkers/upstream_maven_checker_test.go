@@ -0,0 +1,121 @@
+package checkers
+
+import "testing"
+
+func TestIsStableMavenVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"33.0.0-jre", true},
+		{"6.4.4.Final", true},
+		{"5.3.39.RELEASE", true},
+		{"1.0.GA", true},
+		{"2.17.2", true},
+		{"1.0-SNAPSHOT", false},
+		{"1.0.0-snapshot", false},
+		{"2.0.0-M1", false},
+		{"5.0.0.M3", false},
+		{"1.0-alpha-1", false},
+		{"2.0.0-beta2", false},
+		{"5.0.0.RC2", false},
+		{"3.0.0-rc.1", false},
+		{"7.0.0.CR1", false},
+		{"21-ea", false},
+		{"1.0.0-ea+12", false},
+		{"4.0.0-preview1", false},
+		{"3.0.0.Beta1", false},
+		{"1.0rc1", false},
+		{"1.0.0-m", true},
+	}
+	for _, tt := range tests {
+		if got := isStableMavenVersion(tt.version); got != tt.want {
+			t.Errorf("isStableMavenVersion(%q) = %v，期望 %v", tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestMavenCheckerSelectVersion(t *testing.T) {
+	newMetadata := func(release string, versions ...string) *MavenMetadata {
+		metadata := &MavenMetadata{}
+		metadata.Versioning.Release = release
+		metadata.Versioning.Versions = versions
+		return metadata
+	}
+
+	tests := []struct {
+		name             string
+		metadata         *MavenMetadata
+		checkTestVersion int
+		want             string
+	}{
+		{"Hibernate风格", newMetadata("", "6.4.3.Final", "6.4.4.Final", "6.5.0.CR1", "6.5.0.Beta1"), 0, "6.4.4.Final"},
+		{"Hibernate风格检查测试版本", newMetadata("", "6.4.3.Final", "6.4.4.Final", "6.5.0.CR1", "6.5.0.Beta1"), 1, "6.5.0.CR1"},
+		{"Spring风格", newMetadata("", "5.3.38.RELEASE", "5.3.39.RELEASE", "6.0.0-M1"), 0, "5.3.39.RELEASE"},
+		{"跳过SNAPSHOT和里程碑版本", newMetadata("", "1.9.0", "1.10.0", "2.0.0-M2", "2.0.0-SNAPSHOT"), 0, "1.10.0"},
+		{"Guava风格", newMetadata("", "32.1.3-jre", "33.0.0-jre", "33.1.0-jre"), 0, "33.1.0-jre"},
+		{"没有versions时使用release", newMetadata("2.17.2"), 0, "2.17.2"},
+		{"只有测试版本", newMetadata("", "1.0-alpha-1", "1.0-SNAPSHOT"), 0, ""},
+	}
+	checker := NewMavenChecker()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := checker.selectVersion(tt.metadata, tt.checkTestVersion); got != tt.want {
+				t.Errorf("selectVersion() = %q，期望 %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMavenMetadataURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		key     string
+		want    string
+		wantErr bool
+	}{
+		{"maven-metadata.xml地址", "https://maven.example.com/releases/com/example/app/maven-metadata.xml", "", "https://maven.example.com/releases/com/example/app/maven-metadata.xml", false},
+		{"构件页面", "https://central.sonatype.com/artifact/com.google.guava/guava", "", "https://repo1.maven.org/maven2/com/google/guava/guava/maven-metadata.xml", false},
+		{"URL中的坐标", "org.slf4j:slf4j-api", "", "https://repo1.maven.org/maven2/org/slf4j/slf4j-api/maven-metadata.xml", false},
+		{"Maven Central构件目录", "https://repo1.maven.org/maven2/com/google/guava/guava/", "", "https://repo1.maven.org/maven2/com/google/guava/guava/maven-metadata.xml", false},
+		{"Maven Central版本目录", "https://repo1.maven.org/maven2/junit/junit/4.13.2/", "", "https://repo1.maven.org/maven2/junit/junit/maven-metadata.xml", false},
+		{"构件目录和坐标", "https://repo1.maven.org/maven2/com/google/guava/guava/", "org.slf4j:slf4j-api", "https://repo1.maven.org/maven2/org/slf4j/slf4j-api/maven-metadata.xml", false},
+		{"其他仓库根地址和坐标", "https://maven.example.com/releases/", "com.example:app", "https://maven.example.com/releases/com/example/app/maven-metadata.xml", false},
+		{"Maven Central根地址", "https://repo1.maven.org/maven2/", "", "", true},
+		{"只有groupId的目录", "https://repo1.maven.org/maven2/junit/", "", "", true},
+		{"其他仓库的目录", "https://maven.example.com/releases/com/example/app/", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mavenMetadataURL(tt.url, tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("mavenMetadataURL(%q, %q) 错误 = %v，期望返回错误: %v", tt.url, tt.key, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("mavenMetadataURL(%q, %q) = %q，期望 %q", tt.url, tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMavenCheckerSupports(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://central.sonatype.com/artifact/com.google.guava/guava", true},
+		{"https://mvnrepository.com/artifact/org.slf4j/slf4j-api", true},
+		{"https://repo1.maven.org/maven2/com/google/guava/guava/", true},
+		{"https://maven.example.com/releases/com/example/app/maven-metadata.xml", true},
+		{"https://repo1.maven.org/maven2/", false},
+		{"https://repo1.maven.org/maven2/junit/", false},
+		{"https://mvnrepository.com/", false},
+	}
+	checker := NewMavenChecker()
+	for _, tt := range tests {
+		if got := checker.Supports(tt.url); got != tt.want {
+			t.Errorf("Supports(%q) = %v，期望 %v", tt.url, got, tt.want)
+		}
+	}
+}
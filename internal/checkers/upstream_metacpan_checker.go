@@ -0,0 +1,191 @@
+package checkers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	neturl "net/url"
+	"regexp"
+	"strings"
+
+	"aur-update-checker/internal/checkers/common"
+	"aur-update-checker/internal/logger"
+
+	checkerInterfaces "aur-update-checker/internal/interfaces/checkers"
+)
+
+const (
+	// metaCPANAPIURL MetaCPAN API的地址
+	metaCPANAPIURL = "https://fastapi.metacpan.org/v1"
+	// metaCPANDeveloperMaturity 开发版本（版本号带下划线，如 1.23_01）的成熟度
+	metaCPANDeveloperMaturity = "developer"
+)
+
+var (
+	// metaCPANDistPattern 匹配MetaCPAN的发行版页面地址，如 /dist/Moose、/release/Moose、/release/ETHER/Moose-2.2206
+	metaCPANDistPattern = regexp.MustCompile(`metacpan\.org/(?:v1/)?(?:dist/|release/(?:[A-Z0-9-]+/)?)([A-Za-z0-9_.-]+?)(?:-v?[0-9][0-9._]*)?(?:[/?#]|$)`)
+	// metaCPANModulePattern 匹配MetaCPAN的模块文档地址，如 /pod/Moose::Role
+	metaCPANModulePattern = regexp.MustCompile(`metacpan\.org/pod/(?:release/[^/]+/[^/]+/)?([A-Za-z0-9_:]+)`)
+)
+
+// MetaCPANRelease MetaCPAN发行版的版本信息
+type MetaCPANRelease struct {
+	Name           string `json:"name"`
+	Distribution   string `json:"distribution"`
+	Version        string `json:"version"`
+	Author         string `json:"author"`
+	Date           string `json:"date"`
+	Maturity       string `json:"maturity"`
+	DownloadURL    string `json:"download_url"`
+	Archive        string `json:"archive"`
+	ChecksumSHA256 string `json:"checksum_sha256"`
+	Stat           struct {
+		Size int64 `json:"size"`
+	} `json:"stat"`
+}
+
+// MetaCPANChecker MetaCPAN检查器
+type MetaCPANChecker struct {
+	*checkerInterfaces.BaseChecker
+	client *http.Client
+}
+
+// NewMetaCPANChecker 创建MetaCPAN检查器
+func NewMetaCPANChecker() *MetaCPANChecker {
+	return &MetaCPANChecker{
+		BaseChecker: checkerInterfaces.NewBaseChecker("metacpan"),
+		client:      &http.Client{},
+	}
+}
+
+// Supports 检查URL是否是MetaCPAN的发行版或模块地址
+func (c *MetaCPANChecker) Supports(url string) bool {
+	return metaCPANDistPattern.MatchString(url) || metaCPANModulePattern.MatchString(url)
+}
+
+// Priority 专用于MetaCPAN，优先级高于通用检查器
+func (c *MetaCPANChecker) Priority() int {
+	return 80
+}
+
+// Check 实现检查器接口，获取CPAN发行版的最新版本
+func (c *MetaCPANChecker) Check(ctx context.Context, url, versionExtractKey string) (string, error) {
+	// 默认不检查测试版本
+	return c.CheckWithOption(ctx, url, versionExtractKey, 0)
+}
+
+// CheckWithOption 实现检查器接口，根据选项获取CPAN发行版的最新版本
+func (c *MetaCPANChecker) CheckWithOption(ctx context.Context, url, versionExtractKey string, checkTestVersion int) (string, error) {
+	return c.CheckWithVersionRef(ctx, url, versionExtractKey, "", checkTestVersion)
+}
+
+// CheckWithVersionRef 带选项和版本引用地检查上游版本
+func (c *MetaCPANChecker) CheckWithVersionRef(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (string, error) {
+	result, err := c.CheckWithResult(ctx, url, versionExtractKey, versionRef, checkTestVersion)
+	if err != nil {
+		return "", err
+	}
+	return result.Version, nil
+}
+
+// CheckWithResult 实现检查器接口，返回发行版的最新正式版本、发布时间和源码包地址
+// MetaCPAN的 /release/<发行版> 只返回最新的正式版本，不包括开发版本
+func (c *MetaCPANChecker) CheckWithResult(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (*common.UpstreamCheckResult, error) {
+	dist, err := c.distribution(ctx, url, versionExtractKey)
+	if err != nil {
+		logger.GlobalLogger.Errorf("[metacpan] %v", err)
+		return nil, err
+	}
+	logger.GlobalLogger.Debugf("[metacpan] 开始检查CPAN发行版 - 名称: %s", dist)
+
+	var release MetaCPANRelease
+	if err := c.getJSON(ctx, metaCPANAPIURL+"/release/"+neturl.PathEscape(dist), &release); err != nil {
+		logger.GlobalLogger.Errorf("[metacpan] 获取发行版信息失败: %v", err)
+		return nil, fmt.Errorf("获取发行版信息失败: %v", err)
+	}
+	if release.Version == "" {
+		logger.GlobalLogger.Errorf("[metacpan] 发行版 %s 没有版本信息", dist)
+		return nil, fmt.Errorf("发行版 %s 没有版本信息", dist)
+	}
+
+	result := &common.UpstreamCheckResult{
+		Version:      c.BaseChecker.NormalizeVersionWithOption(release.Version, checkTestVersion),
+		TagName:      release.Name,
+		IsPrerelease: release.Maturity == metaCPANDeveloperMaturity,
+		ReleaseDate:  common.ParseReleaseDate(release.Date),
+		SourceURL:    fmt.Sprintf("https://metacpan.org/release/%s/%s", release.Author, release.Name),
+	}
+	if release.DownloadURL != "" {
+		result.Assets = []common.ReleaseAsset{{
+			Name:        release.Archive,
+			DownloadURL: release.DownloadURL,
+			Size:        release.Stat.Size,
+			SHA256:      release.ChecksumSHA256,
+			Default:     true,
+		}}
+	}
+
+	logger.GlobalLogger.Infof("[metacpan] 发行版 %s 的最新版本: %s", dist, result.Version)
+	return result, nil
+}
+
+// distribution 返回发行版名称
+// URL是模块文档地址时通过 /module/<模块名> 查询模块所在的发行版；URL不是MetaCPAN地址时使用versionExtractKey，
+// versionExtractKey中的模块名（如 Moose::Role）同样会查询所在的发行版
+func (c *MetaCPANChecker) distribution(ctx context.Context, url, versionExtractKey string) (string, error) {
+	if matches := metaCPANDistPattern.FindStringSubmatch(url); len(matches) > 1 {
+		return matches[1], nil
+	}
+
+	module := ""
+	if matches := metaCPANModulePattern.FindStringSubmatch(url); len(matches) > 1 {
+		module = matches[1]
+	} else if strings.Contains(versionExtractKey, "::") {
+		module = versionExtractKey
+	} else if versionExtractKey != "" {
+		return versionExtractKey, nil
+	}
+	if module == "" {
+		return "", fmt.Errorf("无法从URL中提取CPAN发行版名称: %s", url)
+	}
+
+	var info struct {
+		Distribution string `json:"distribution"`
+	}
+	if err := c.getJSON(ctx, metaCPANAPIURL+"/module/"+neturl.PathEscape(module), &info); err != nil {
+		return "", fmt.Errorf("查询模块 %s 所在的发行版失败: %v", module, err)
+	}
+	if info.Distribution == "" {
+		return "", fmt.Errorf("模块 %s 没有所属的发行版", module)
+	}
+	return info.Distribution, nil
+}
+
+// getJSON 发送GET请求并解析JSON响应
+func (c *MetaCPANChecker) getJSON(ctx context.Context, apiURL string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return fmt.Errorf("创建请求失败: %v", err)
+	}
+	req.Header.Set("User-Agent", "aur-update-checker")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("请求失败: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("地址不存在: %s", apiURL)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("请求失败，状态码: %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("解析响应失败: %v", err)
+	}
+	return nil
+}
@@ -1,17 +1,27 @@
 package checkers
 
 import (
+	"aur-update-checker/internal/checkers/common"
+	"aur-update-checker/internal/config"
 	"aur-update-checker/internal/logger"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"regexp"
+	"strings"
 
 	checkerInterfaces "aur-update-checker/internal/interfaces/checkers"
 )
 
+// OptionDistTag 软件包检查器选项：跟踪的npm dist-tag，如 next、beta，默认 latest
+const OptionDistTag = "dist_tag"
+
+// defaultNpmRegistries 默认的NPM仓库，按顺序尝试，前一个请求失败时使用下一个
+var defaultNpmRegistries = []string{"https://registry.npmjs.org", "https://registry.npmmirror.com"}
+
 // NpmPackage NPM包信息
 type NpmPackage struct {
 	Name        string            `json:"name"`
@@ -19,12 +29,18 @@ type NpmPackage struct {
 	Version     string            `json:"version"`
 	DistTags    map[string]string `json:"dist-tags"`
 	Versions    map[string]interface{} `json:"versions"`
+	Time        map[string]string `json:"time"` // 各版本的发布时间
 }
 
 // NpmChecker NPM检查器
 type NpmChecker struct {
 	*checkerInterfaces.BaseChecker
-	client *http.Client
+	client     *http.Client
+	registries []string
+	// scopes @scope 到仓库地址的映射，作用域包只从对应的仓库获取
+	scopes map[string]string
+	// tokens 仓库地址到访问令牌的映射，与 .npmrc 相同，令牌只发送给对应的仓库
+	tokens map[string]string
 }
 
 // NewNpmChecker 创建NPM检查器
@@ -32,6 +48,30 @@ func NewNpmChecker() *NpmChecker {
 	return &NpmChecker{
 		BaseChecker: checkerInterfaces.NewBaseChecker("npm"),
 		client:      &http.Client{},
+		registries:  defaultNpmRegistries,
+	}
+}
+
+// ApplySettings 应用检查器配置，从CustomParams中读取仓库列表、作用域仓库和各仓库的访问令牌
+func (c *NpmChecker) ApplySettings(settings config.CheckerSettings) {
+	if registries := common.GetStringSliceParam(settings.CustomParams, "registries"); len(registries) > 0 {
+		c.registries = make([]string, 0, len(registries))
+		for _, registry := range registries {
+			c.registries = append(c.registries, strings.TrimSuffix(registry, "/"))
+		}
+	}
+	c.scopes = make(map[string]string)
+	for scope, registry := range common.GetStringMapParam(settings.CustomParams, "scopes") {
+		if !strings.HasPrefix(scope, "@") {
+			scope = "@" + scope
+		}
+		c.scopes[scope] = strings.TrimSuffix(registry, "/")
+	}
+	c.tokens = make(map[string]string)
+	for registry, token := range common.GetStringMapParam(settings.CustomParams, "tokens") {
+		if token != "" {
+			c.tokens[npmRegistryKey(registry)] = token
+		}
 	}
 }
 
@@ -43,31 +83,8 @@ func (c *NpmChecker) Check(ctx context.Context, url, versionExtractKey string) (
 
 // CheckWithOption 实现检查器接口，根据选项从NPM获取包版本
 func (c *NpmChecker) CheckWithOption(ctx context.Context, url, versionExtractKey string, checkTestVersion int) (string, error) {
-	// 从URL或versionExtractKey中提取包名
-	packageName, err := c.extractPackageName(url, versionExtractKey)
-	if err != nil {
-		errMsg := fmt.Errorf("提取NPM包名失败: %v", err)
-		logger.GlobalLogger.Errorf("[npm] %v", errMsg)
-		return "", errMsg
-	}
-
-	// 获取NPM包信息
-	packageInfo, err := c.fetchPackageInfo(ctx, packageName)
-	if err != nil {
-		logger.GlobalLogger.Errorf("[npm] 获取NPM包信息失败: %v", err)
-		return "", fmt.Errorf("获取NPM包信息失败: %v", err)
-	}
-
-	// 提取版本
-	version, err := c.extractVersionWithOption(packageInfo, versionExtractKey, checkTestVersion)
-	if err != nil {
-		logger.GlobalLogger.Errorf("[npm] 提取版本失败: %v", err)
-		return "", fmt.Errorf("提取版本失败: %v", err)
-	}
-
-	// 规范化版本号，移除平台特定信息
-	normalizedVersion := c.BaseChecker.NormalizeVersionWithOption(version, checkTestVersion)
-	return normalizedVersion, nil
+	// 调用CheckWithVersionRef方法，传入空版本引用
+	return c.CheckWithVersionRef(ctx, url, versionExtractKey, "", checkTestVersion)
 }
 
 // extractPackageName 从URL或versionExtractKey中提取包名
@@ -81,8 +98,8 @@ func (c *NpmChecker) extractPackageName(url, versionExtractKey string) (string,
 	}
 
 	// 尝试从URL中提取包名
-	// 匹配npmjs.com/package/<package-name>格式
-	re := regexp.MustCompile(`npmjs\.com/package/([^/\s]+)`)
+	// 匹配npmjs.com/package/<package-name>格式，包括 @scope/name 形式的作用域包
+	re := regexp.MustCompile(`npmjs\.com/package/(@[^/\s?#]+/[^/\s?#]+|[^/\s?#]+)`)
 	matches := re.FindStringSubmatch(url)
 	if len(matches) >= 2 {
 		return matches[1], nil
@@ -112,16 +129,51 @@ func (c *NpmChecker) isValidPackageName(name string) bool {
 	return true
 }
 
-// fetchPackageInfo 获取NPM包信息
+// packageRegistries 返回获取包信息时依次尝试的仓库，作用域包配置了仓库时只使用该仓库
+func (c *NpmChecker) packageRegistries(packageName string) []string {
+	if strings.HasPrefix(packageName, "@") {
+		scope := strings.SplitN(packageName, "/", 2)[0]
+		if registry, ok := c.scopes[scope]; ok {
+			return []string{registry}
+		}
+	}
+	return c.registries
+}
+
+// fetchPackageInfo 获取NPM包信息，按顺序尝试各个仓库，返回第一个成功的结果
 func (c *NpmChecker) fetchPackageInfo(ctx context.Context, packageName string) (*NpmPackage, error) {
-	// 使用国内NPM镜像源解决访问问题
-	apiURL := fmt.Sprintf("https://registry.npmmirror.com/%s", packageName)
+	var lastErr error
+	for _, registry := range c.packageRegistries(packageName) {
+		packageInfo, err := c.fetchPackageInfoFrom(ctx, registry, packageName)
+		if err == nil {
+			return packageInfo, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		logger.GlobalLogger.Warnf("[npm] 从仓库 %s 获取包 %s 失败: %v", registry, packageName, err)
+		lastErr = err
+	}
+	if lastErr == nil {
+		return nil, fmt.Errorf("未配置NPM仓库")
+	}
+	return nil, lastErr
+}
+
+// fetchPackageInfoFrom 从指定仓库获取NPM包信息
+func (c *NpmChecker) fetchPackageInfoFrom(ctx context.Context, registry, packageName string) (*NpmPackage, error) {
+	// 作用域包名中的 / 需要转义，如 @scope%2Fname
+	apiURL := registry + "/" + url.PathEscape(packageName)
 
 	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
 	if err != nil {
 		logger.GlobalLogger.Errorf("[npm] 创建请求失败: %v", err)
 		return nil, fmt.Errorf("创建请求失败: %v", err)
 	}
+	req.Header.Set("Accept", "application/json")
+	if token, ok := c.tokens[npmRegistryKey(registry)]; ok {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
 
 	resp, err := c.client.Do(req)
 	if err != nil {
@@ -148,11 +200,21 @@ func (c *NpmChecker) fetchPackageInfo(ctx context.Context, packageName string) (
 	return &packageInfo, nil
 }
 
+// npmRegistryKey 返回仓库地址用于匹配访问令牌的形式，忽略协议和末尾的 /，
+// 如 https://npm.example.com/ 和 .npmrc 中的 //npm.example.com/ 都是 //npm.example.com
+func npmRegistryKey(registry string) string {
+	registry = strings.TrimSpace(registry)
+	if i := strings.Index(registry, "//"); i >= 0 {
+		registry = registry[i:]
+	}
+	return strings.TrimSuffix(registry, "/")
+}
+
 // extractVersionWithOption 根据选项从包信息中提取版本
-func (c *NpmChecker) extractVersionWithOption(packageInfo *NpmPackage, versionExtractKey string, checkTestVersion int) (string, error) {
-	// 如果versionExtractKey为空，使用latest标签
+func (c *NpmChecker) extractVersionWithOption(packageInfo *NpmPackage, versionExtractKey, distTag string, checkTestVersion int) (string, error) {
+	// 如果versionExtractKey为空，使用distTag标签，默认为latest
 	if versionExtractKey == "" {
-		if latest, ok := packageInfo.DistTags["latest"]; ok {
+		if latest, ok := packageInfo.DistTags[distTag]; ok {
 			// 根据checkTestVersion参数决定是否检查测试版本
 			if checkTestVersion > 0 {
 				return latest, nil
@@ -203,36 +265,87 @@ func (c *NpmChecker) extractVersionWithOption(packageInfo *NpmPackage, versionEx
 
 // CheckWithVersionRef 带选项和版本引用地检查上游版本
 func (c *NpmChecker) CheckWithVersionRef(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (string, error) {
-	// 如果versionRef为空，使用默认的CheckWithOption方法
-	if versionRef == "" {
-		return c.CheckWithOption(ctx, url, versionExtractKey, checkTestVersion)
+	result, err := c.CheckWithResult(ctx, url, versionExtractKey, versionRef, checkTestVersion)
+	if err != nil {
+		return "", err
 	}
+	return result.Version, nil
+}
 
+// CheckWithResult 实现检查器接口，返回NPM包的版本和该版本的发布时间
+func (c *NpmChecker) CheckWithResult(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (*common.UpstreamCheckResult, error) {
 	// 从URL或versionExtractKey中提取包名
 	packageName, err := c.extractPackageName(url, versionExtractKey)
 	if err != nil {
 		errMsg := fmt.Errorf("提取NPM包名失败: %v", err)
 		logger.GlobalLogger.Errorf("[npm] %v", errMsg)
-		return "", errMsg
+		return nil, errMsg
+	}
+
+	// 版本提取关键字已用作包名时，不再作为标签或提取规则
+	if versionExtractKey == packageName {
+		versionExtractKey = ""
 	}
 
 	// 获取NPM包信息
 	packageInfo, err := c.fetchPackageInfo(ctx, packageName)
 	if err != nil {
 		logger.GlobalLogger.Errorf("[npm] 获取NPM包信息失败: %v", err)
-		return "", fmt.Errorf("获取NPM包信息失败: %v", err)
+		return nil, fmt.Errorf("获取NPM包信息失败: %v", err)
 	}
 
-	// 检查versionRef是否是有效的版本号
-	if _, ok := packageInfo.Versions[versionRef]; ok {
+	distTag := common.CheckOptionsFromContext(ctx).Get(OptionDistTag, "latest")
+	if _, ok := packageInfo.DistTags[distTag]; !ok && distTag != "latest" {
+		logger.GlobalLogger.Errorf("[npm] 包 %s 没有dist-tag: %s", packageName, distTag)
+		return nil, fmt.Errorf("包 %s 没有dist-tag: %s", packageName, distTag)
+	}
+
+	var rawVersion, version string
+	if _, ok := packageInfo.Versions[versionRef]; versionRef != "" && ok {
 		// 如果versionRef是有效的版本号，使用它
+		rawVersion = versionRef
 		normalized := c.BaseChecker.NormalizeVersionWithOption(versionRef, checkTestVersion)
 		// 标准化版本号，移除前缀如 'v'
-		standardized := c.BaseChecker.StandardizeVersion(normalized)
-		return standardized, nil
+		version = c.BaseChecker.StandardizeVersion(normalized)
+	} else {
+		if versionRef != "" {
+			logger.GlobalLogger.Warnf("[npm] versionRef '%s' 不是有效的版本号，将使用默认方法获取版本", versionRef)
+		}
+
+		// 提取版本
+		extracted, err := c.extractVersionWithOption(packageInfo, versionExtractKey, distTag, checkTestVersion)
+		if err != nil {
+			logger.GlobalLogger.Errorf("[npm] 提取版本失败: %v", err)
+			return nil, fmt.Errorf("提取版本失败: %v", err)
+		}
+
+		rawVersion = c.selectedVersion(packageInfo, versionExtractKey, distTag)
+		// 规范化版本号，移除平台特定信息
+		version = c.BaseChecker.NormalizeVersionWithOption(extracted, checkTestVersion)
 	}
 
-	// 如果versionRef不是有效的版本号，使用默认的CheckWithOption方法
-	logger.GlobalLogger.Warnf("[npm] versionRef '%s' 不是有效的版本号，将使用默认方法获取版本", versionRef)
-	return c.CheckWithOption(ctx, url, versionExtractKey, checkTestVersion)
+	return &common.UpstreamCheckResult{
+		Version: version,
+		// NPM版本号遵循语义化版本，带有'-'后缀的是预发布版本
+		IsPrerelease: strings.Contains(rawVersion, "-"),
+		ReleaseDate:  common.ParseReleaseDate(packageInfo.Time[rawVersion]),
+		SourceURL:    fmt.Sprintf("https://www.npmjs.com/package/%s/v/%s", packageName, rawVersion),
+	}, nil
+}
+
+// selectedVersion 返回extractVersionWithOption所使用的原始版本号
+func (c *NpmChecker) selectedVersion(packageInfo *NpmPackage, versionExtractKey, distTag string) string {
+	if versionExtractKey == "" {
+		if latest, ok := packageInfo.DistTags[distTag]; ok {
+			return latest
+		}
+		return packageInfo.Version
+	}
+	if tag, ok := packageInfo.DistTags[versionExtractKey]; ok {
+		return tag
+	}
+	if _, ok := packageInfo.Versions[versionExtractKey]; ok {
+		return versionExtractKey
+	}
+	return packageInfo.Version
 }
@@ -0,0 +1,71 @@
+package checkers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"aur-update-checker/internal/config"
+)
+
+func TestNpmCheckerRegistryTokens(t *testing.T) {
+	var mu sync.Mutex
+	authorizations := make(map[string]string)
+	newRegistry := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			authorizations[name] = r.Header.Get("Authorization")
+			mu.Unlock()
+			w.Write([]byte(`{"name": "pkg", "dist-tags": {"latest": "1.2.3"}, "versions": {"1.2.3": {}}}`))
+		}))
+	}
+	public := newRegistry("public")
+	defer public.Close()
+	private := newRegistry("private")
+	defer private.Close()
+	scoped := newRegistry("scoped")
+	defer scoped.Close()
+
+	checker := NewNpmChecker()
+	checker.ApplySettings(config.CheckerSettings{CustomParams: map[string]interface{}{
+		"registries": []interface{}{public.URL, private.URL + "/"},
+		"scopes":     map[string]interface{}{"@corp": scoped.URL},
+		"tokens": map[string]interface{}{
+			// 与 .npmrc 相同，可以省略协议
+			private.URL[len("http:"):] + "/": "private-token",
+			scoped.URL:                       "scoped-token",
+		},
+	}})
+
+	tests := []struct {
+		name     string
+		pkg      string
+		registry string
+		want     string
+	}{
+		{"没有配置令牌的仓库", "pkg", "public", ""},
+		{"作用域仓库使用对应的令牌", "@corp/pkg", "scoped", "Bearer scoped-token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := checker.fetchPackageInfo(context.Background(), tt.pkg); err != nil {
+				t.Fatalf("fetchPackageInfo(%q) 返回错误: %v", tt.pkg, err)
+			}
+			if got := authorizations[tt.registry]; got != tt.want {
+				t.Errorf("仓库 %s 收到的 Authorization = %q，期望 %q", tt.registry, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := checker.fetchPackageInfoFrom(context.Background(), checker.registries[1], "pkg"); err != nil {
+		t.Fatalf("fetchPackageInfoFrom 返回错误: %v", err)
+	}
+	if got := authorizations["private"]; got != "Bearer private-token" {
+		t.Errorf("私有仓库收到的 Authorization = %q，期望 %q", got, "Bearer private-token")
+	}
+	if got := authorizations["public"]; got != "" {
+		t.Errorf("令牌被发送给了未配置令牌的仓库: %q", got)
+	}
+}
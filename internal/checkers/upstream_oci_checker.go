@@ -0,0 +1,351 @@
+package checkers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"aur-update-checker/internal/checkers/common"
+	"aur-update-checker/internal/logger"
+
+	checkerInterfaces "aur-update-checker/internal/interfaces/checkers"
+)
+
+const (
+	// dockerHubRegistry Docker Hub镜像仓库的API地址
+	dockerHubRegistry = "registry-1.docker.io"
+	// ociTagsPageSize 每页请求的标签数量，仓库可能返回更少
+	ociTagsPageSize = 1000
+	// ociMaxTagPages 最多请求的标签页数
+	ociMaxTagPages = 50
+)
+
+var (
+	// dockerHubPagePattern 匹配Docker Hub的镜像页面地址，如 https://hub.docker.com/r/<命名空间>/<镜像> 和 https://hub.docker.com/_/<镜像>
+	dockerHubPagePattern = regexp.MustCompile(`^https?://hub\.docker\.com/(?:r/([^/]+)/([^/?#]+)|_/([^/?#]+))`)
+	// authParamPattern 匹配WWW-Authenticate响应头中的参数
+	authParamPattern = regexp.MustCompile(`(\w+)="([^"]*)"`)
+)
+
+// OCITagList 镜像仓库 /v2/<名称>/tags/list 的响应
+type OCITagList struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+}
+
+// ociImage 镜像仓库地址和镜像名称
+type ociImage struct {
+	scheme   string
+	registry string
+	name     string
+}
+
+// OCIChecker OCI/Docker镜像仓库标签检查器
+// 使用Registry HTTP API v2列出镜像的全部标签，支持匿名Bearer令牌认证和Link响应头分页
+type OCIChecker struct {
+	*checkerInterfaces.BaseChecker
+	client *http.Client
+}
+
+// NewOCIChecker 创建OCI镜像仓库检查器
+func NewOCIChecker() *OCIChecker {
+	return &OCIChecker{
+		BaseChecker: checkerInterfaces.NewBaseChecker("oci"),
+		client:      &http.Client{},
+	}
+}
+
+// Supports 检查URL是否是镜像地址或Docker Hub镜像页面
+func (c *OCIChecker) Supports(url string) bool {
+	lower := strings.ToLower(url)
+	return strings.HasPrefix(lower, "oci://") || strings.HasPrefix(lower, "docker://") ||
+		dockerHubPagePattern.MatchString(url) || (strings.Contains(lower, "/v2/") && strings.HasSuffix(lower, "/tags/list"))
+}
+
+// Priority 专用于镜像仓库，优先级高于通用检查器
+func (c *OCIChecker) Priority() int {
+	return 70
+}
+
+// Check 实现检查器接口，获取镜像的最新版本标签
+func (c *OCIChecker) Check(ctx context.Context, url, versionExtractKey string) (string, error) {
+	// 默认不检查测试版本
+	return c.CheckWithOption(ctx, url, versionExtractKey, 0)
+}
+
+// CheckWithOption 实现检查器接口，根据选项获取镜像的最新版本标签
+func (c *OCIChecker) CheckWithOption(ctx context.Context, url, versionExtractKey string, checkTestVersion int) (string, error) {
+	return c.CheckWithVersionRef(ctx, url, versionExtractKey, "", checkTestVersion)
+}
+
+// CheckWithVersionRef 带选项和版本引用地检查上游版本
+func (c *OCIChecker) CheckWithVersionRef(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (string, error) {
+	result, err := c.CheckWithResult(ctx, url, versionExtractKey, versionRef, checkTestVersion)
+	if err != nil {
+		return "", err
+	}
+	return result.Version, nil
+}
+
+// CheckWithResult 实现检查器接口，列出镜像的全部标签并选出版本号最大的标签
+// versionExtractKey 是标签过滤正则表达式，有捕获组时使用第一个捕获组作为版本号，为空时使用全部标签
+func (c *OCIChecker) CheckWithResult(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (*common.UpstreamCheckResult, error) {
+	image, err := parseOCIImage(url)
+	if err != nil {
+		logger.GlobalLogger.Errorf("[oci] %v", err)
+		return nil, err
+	}
+	logger.GlobalLogger.Debugf("[oci] 开始检查镜像 - 仓库: %s, 镜像: %s, 标签过滤: %s", image.registry, image.name, versionExtractKey)
+
+	var filter *regexp.Regexp
+	if versionExtractKey != "" {
+		re, err := regexp.Compile(versionExtractKey)
+		if err != nil {
+			logger.GlobalLogger.Errorf("[oci] 编译标签过滤正则表达式失败: %v", err)
+			return nil, fmt.Errorf("编译标签过滤正则表达式失败: %v", err)
+		}
+		filter = re
+	}
+
+	tags, err := c.fetchTags(ctx, image)
+	if err != nil {
+		logger.GlobalLogger.Errorf("[oci] 获取标签列表失败: %v", err)
+		return nil, fmt.Errorf("获取标签列表失败: %v", err)
+	}
+
+	result, err := selectHighestTag(c.BaseChecker, ociVersionTags(tags), filter, checkTestVersion)
+	if err != nil {
+		logger.GlobalLogger.Errorf("[oci] %v", err)
+		return nil, err
+	}
+
+	result.SourceURL = image.tagsURL()
+	logger.GlobalLogger.Infof("[oci] 从%d个标签中选择 %s，版本: %s", len(tags), result.TagName, result.Version)
+	return result, nil
+}
+
+// fetchTags 按Link响应头分页获取镜像的全部标签
+func (c *OCIChecker) fetchTags(ctx context.Context, image *ociImage) ([]string, error) {
+	pageURL := setQueryParam(image.tagsURL(), "n", fmt.Sprint(ociTagsPageSize))
+	token := ""
+
+	var tags []string
+	for page := 0; pageURL != "" && page < ociMaxTagPages; page++ {
+		resp, err := c.get(ctx, pageURL, token)
+		if err != nil {
+			return nil, err
+		}
+
+		// 仓库要求认证时，按WWW-Authenticate响应头获取匿名令牌后重试
+		if resp.StatusCode == http.StatusUnauthorized && token == "" {
+			challenge := resp.Header.Get("WWW-Authenticate")
+			resp.Body.Close()
+			if token, err = c.fetchToken(ctx, challenge, image); err != nil {
+				return nil, err
+			}
+			if resp, err = c.get(ctx, pageURL, token); err != nil {
+				return nil, err
+			}
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("请求失败，状态码: %d", resp.StatusCode)
+		}
+
+		var list OCITagList
+		err = json.NewDecoder(resp.Body).Decode(&list)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("解析标签列表失败: %v", err)
+		}
+		tags = append(tags, list.Tags...)
+
+		pageURL = ""
+		if matches := linkNextPattern.FindStringSubmatch(resp.Header.Get("Link")); len(matches) > 1 {
+			pageURL = resolveReference(resp.Request.URL, matches[1])
+		}
+	}
+
+	logger.GlobalLogger.Debugf("[oci] 镜像 %s 共有%d个标签", image.name, len(tags))
+	return tags, nil
+}
+
+// fetchToken 按Bearer认证质询获取匿名拉取令牌
+// 质询格式为 Bearer realm="<令牌服务地址>",service="<服务名>",scope="<权限范围>"
+func (c *OCIChecker) fetchToken(ctx context.Context, challenge string, image *ociImage) (string, error) {
+	scheme, params, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", fmt.Errorf("镜像仓库要求 %s 认证，只支持匿名Bearer令牌认证", scheme)
+	}
+
+	values := make(map[string]string)
+	for _, matches := range authParamPattern.FindAllStringSubmatch(params, -1) {
+		values[strings.ToLower(matches[1])] = matches[2]
+	}
+	realm := values["realm"]
+	if realm == "" {
+		return "", fmt.Errorf("认证质询中没有令牌服务地址: %s", challenge)
+	}
+
+	query := url.Values{}
+	if service := values["service"]; service != "" {
+		query.Set("service", service)
+	}
+	scope := values["scope"]
+	if scope == "" {
+		scope = fmt.Sprintf("repository:%s:pull", image.name)
+	}
+	query.Set("scope", scope)
+
+	tokenURL := realm
+	if strings.Contains(tokenURL, "?") {
+		tokenURL += "&" + query.Encode()
+	} else {
+		tokenURL += "?" + query.Encode()
+	}
+
+	resp, err := c.get(ctx, tokenURL, "")
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("获取匿名令牌失败，状态码: %d", resp.StatusCode)
+	}
+
+	var tokenResp struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return "", fmt.Errorf("解析令牌响应失败: %v", err)
+	}
+	if tokenResp.Token != "" {
+		return tokenResp.Token, nil
+	}
+	if tokenResp.AccessToken != "" {
+		return tokenResp.AccessToken, nil
+	}
+	return "", fmt.Errorf("令牌响应中没有令牌")
+}
+
+// get 发送GET请求，token不为空时携带Bearer令牌，由调用方检查状态码
+func (c *OCIChecker) get(ctx context.Context, rawURL, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %v", err)
+	}
+	req.Header.Set("User-Agent", "aur-update-checker")
+	req.Header.Set("Accept", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("请求失败: %v", err)
+	}
+	return resp, nil
+}
+
+// tagsURL 返回镜像标签列表API地址
+func (i *ociImage) tagsURL() string {
+	return fmt.Sprintf("%s://%s/v2/%s/tags/list", i.scheme, i.registry, i.name)
+}
+
+// parseOCIImage 解析镜像地址
+// 支持 oci://、docker:// 前缀的镜像引用（如 oci://ghcr.io/owner/image、docker://nginx、oci://localhost:5000/image），
+// Docker Hub镜像页面，以及镜像仓库API地址（如 http://localhost:5000/v2/image/tags/list）
+// 不带主机名的镜像使用Docker Hub，Docker Hub的官方镜像需要加上 library/ 前缀
+func parseOCIImage(rawURL string) (*ociImage, error) {
+	rawURL = strings.TrimSpace(rawURL)
+
+	if matches := dockerHubPagePattern.FindStringSubmatch(rawURL); matches != nil {
+		if matches[3] != "" {
+			return &ociImage{scheme: "https", registry: dockerHubRegistry, name: "library/" + matches[3]}, nil
+		}
+		return &ociImage{scheme: "https", registry: dockerHubRegistry, name: matches[1] + "/" + matches[2]}, nil
+	}
+
+	image := &ociImage{scheme: "https"}
+	reference := rawURL
+	for _, prefix := range []string{"oci://", "docker://"} {
+		reference = strings.TrimPrefix(reference, prefix)
+	}
+	explicitScheme := true
+	if strings.HasPrefix(reference, "http://") {
+		image.scheme = "http"
+		reference = strings.TrimPrefix(reference, "http://")
+	} else if strings.HasPrefix(reference, "https://") {
+		reference = strings.TrimPrefix(reference, "https://")
+	} else {
+		explicitScheme = false
+	}
+
+	// 镜像仓库API地址 <主机>/v2/<名称>/tags/list
+	if i := strings.Index(reference, "/v2/"); i >= 0 {
+		image.registry = reference[:i]
+		image.name = strings.TrimSuffix(strings.TrimSuffix(reference[i+len("/v2/"):], "/"), "/tags/list")
+	} else {
+		// 去掉标签和摘要
+		if i := strings.Index(reference, "@"); i >= 0 {
+			reference = reference[:i]
+		}
+		if i := strings.LastIndex(reference, ":"); i > strings.LastIndex(reference, "/") {
+			reference = reference[:i]
+		}
+		reference = strings.TrimSuffix(reference, "/")
+
+		// 第一段包含 . 或 : 或为 localhost 时是主机名
+		first, rest, found := strings.Cut(reference, "/")
+		if found && (strings.ContainsAny(first, ".:") || first == "localhost") {
+			image.registry = first
+			image.name = rest
+		} else {
+			image.registry = "docker.io"
+			image.name = reference
+		}
+	}
+
+	// 与docker一致，本机上的镜像仓库（如测试用的registry:2）默认使用HTTP
+	if !explicitScheme && (strings.HasPrefix(image.registry, "localhost") || strings.HasPrefix(image.registry, "127.0.0.1")) {
+		image.scheme = "http"
+	}
+	if image.registry == "docker.io" || image.registry == "index.docker.io" {
+		image.registry = dockerHubRegistry
+	}
+	if image.registry == dockerHubRegistry && image.name != "" && !strings.Contains(image.name, "/") {
+		image.name = "library/" + image.name
+	}
+	if image.registry == "" || image.name == "" {
+		return nil, fmt.Errorf("无法从URL中解析镜像地址: %s", rawURL)
+	}
+	return image, nil
+}
+
+// ociVersionTags 去掉签名、证明等附属产物的标签，如cosign生成的 sha256-<摘要>.sig
+func ociVersionTags(tags []string) []string {
+	var result []string
+	for _, tag := range tags {
+		if strings.HasPrefix(tag, "sha256-") {
+			continue
+		}
+		result = append(result, tag)
+	}
+	return result
+}
+
+// resolveReference 将Link响应头中的相对地址解析为绝对地址
+func resolveReference(base *url.URL, reference string) string {
+	ref, err := url.Parse(reference)
+	if err != nil {
+		return ""
+	}
+	return base.ResolveReference(ref).String()
+}
@@ -0,0 +1,233 @@
+package checkers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+const (
+	ociTestToken   = "anonymous-pull-token"
+	ociTestService = "registry.test"
+)
+
+// ociTestTagPages 按 last 参数分页的标签列表，与distribution的实现一致，下一页从上一页最后一个标签之后开始
+var ociTestTagPages = map[string][]string{
+	"":           {"v1.0.0", "v1.2.0", "latest"},
+	"latest":     {"v1.10.0", "sha256-0123abcd.sig", "v2.0.0-rc1"},
+	"v2.0.0-rc1": {"v1.9.0"},
+}
+
+// newOCITestRegistry 模拟要求匿名Bearer令牌的镜像仓库，令牌服务与仓库在同一个服务器上
+func newOCITestRegistry(t *testing.T, tokenRequests *int32) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			atomic.AddInt32(tokenRequests, 1)
+			query := r.URL.Query()
+			if query.Get("service") != ociTestService || query.Get("scope") != "repository:team/app:pull" {
+				http.Error(w, "invalid scope", http.StatusBadRequest)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]string{"token": ociTestToken})
+		case r.URL.Path == "/v2/team/app/tags/list":
+			if r.Header.Get("Authorization") != "Bearer "+ociTestToken {
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="%s",scope="repository:team/app:pull"`, server.URL, ociTestService))
+				http.Error(w, `{"errors":[{"code":"UNAUTHORIZED"}]}`, http.StatusUnauthorized)
+				return
+			}
+			last := r.URL.Query().Get("last")
+			tags, ok := ociTestTagPages[last]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			if last == "" && r.URL.Query().Get("n") == "" {
+				http.Error(w, "missing page size", http.StatusBadRequest)
+				return
+			}
+			if next := tags[len(tags)-1]; ociTestTagPages[next] != nil {
+				w.Header().Set("Link", fmt.Sprintf(`</v2/team/app/tags/list?last=%s&n=3>; rel="next"`, next))
+			}
+			json.NewEncoder(w).Encode(OCITagList{Name: "team/app", Tags: tags})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestOCICheckerFetchTags(t *testing.T) {
+	var tokenRequests int32
+	server := newOCITestRegistry(t, &tokenRequests)
+
+	image, err := parseOCIImage(server.URL + "/v2/team/app/tags/list")
+	if err != nil {
+		t.Fatalf("parseOCIImage 返回错误: %v", err)
+	}
+	tags, err := NewOCIChecker().fetchTags(context.Background(), image)
+	if err != nil {
+		t.Fatalf("fetchTags 返回错误: %v", err)
+	}
+	want := []string{"v1.0.0", "v1.2.0", "latest", "v1.10.0", "sha256-0123abcd.sig", "v2.0.0-rc1", "v1.9.0"}
+	if !reflect.DeepEqual(tags, want) {
+		t.Errorf("fetchTags = %q，期望 %q", tags, want)
+	}
+	// 令牌在后续分页请求中复用
+	if tokenRequests != 1 {
+		t.Errorf("请求了%d次令牌，期望1次", tokenRequests)
+	}
+}
+
+func TestOCICheckerCheckWithResult(t *testing.T) {
+	var tokenRequests int32
+	server := newOCITestRegistry(t, &tokenRequests)
+
+	tests := []struct {
+		name             string
+		key              string
+		checkTestVersion int
+		wantVersion      string
+		wantTag          string
+		wantErr          bool
+	}{
+		{"跨页选择最大的版本", "", 0, "1.10.0", "v1.10.0", false},
+		{"检查测试版本", "", 1, "2.0.0-rc1", "v2.0.0-rc1", false},
+		{"标签过滤捕获组", `^v(1\.\d+)\.0$`, 0, "1.10", "v1.10.0", false},
+		{"没有匹配的标签", `^release-`, 0, "", "", true},
+	}
+	checker := NewOCIChecker()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := checker.CheckWithResult(context.Background(), server.URL+"/v2/team/app/tags/list", tt.key, "", tt.checkTestVersion)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckWithResult(%q) 错误 = %v，期望返回错误: %v", tt.key, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if result.Version != tt.wantVersion || result.TagName != tt.wantTag {
+				t.Errorf("CheckWithResult(%q) = %q (%q)，期望 %q (%q)", tt.key, result.Version, result.TagName, tt.wantVersion, tt.wantTag)
+			}
+		})
+	}
+
+	if _, err := checker.CheckWithResult(context.Background(), server.URL+"/v2/team/missing/tags/list", "", "", 0); err == nil {
+		t.Errorf("镜像不存在时期望返回错误")
+	}
+}
+
+func TestOCICheckerFetchToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch r.URL.Path {
+		case "/token":
+			// 返回请求的参数，便于检查
+			json.NewEncoder(w).Encode(map[string]string{"token": query.Get("service") + "|" + query.Get("scope") + "|" + query.Get("account")})
+		case "/oauth":
+			json.NewEncoder(w).Encode(map[string]string{"access_token": "oauth-token"})
+		case "/empty":
+			json.NewEncoder(w).Encode(map[string]string{})
+		default:
+			http.Error(w, "denied", http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	image := &ociImage{scheme: "http", registry: "registry.test", name: "team/app"}
+	tests := []struct {
+		name      string
+		challenge string
+		want      string
+		wantErr   bool
+	}{
+		{"完整的质询", fmt.Sprintf(`Bearer realm="%s/token",service="registry.test",scope="repository:team/app:pull"`, server.URL), "registry.test|repository:team/app:pull|", false},
+		{"没有scope时使用镜像名称", fmt.Sprintf(`bearer realm="%s/token"`, server.URL), "|repository:team/app:pull|", false},
+		{"realm中已有查询参数", fmt.Sprintf(`Bearer realm="%s/token?account=guest",service="registry.test"`, server.URL), "registry.test|repository:team/app:pull|guest", false},
+		{"access_token字段", fmt.Sprintf(`Bearer realm="%s/oauth"`, server.URL), "oauth-token", false},
+		{"令牌响应中没有令牌", fmt.Sprintf(`Bearer realm="%s/empty"`, server.URL), "", true},
+		{"令牌服务拒绝", fmt.Sprintf(`Bearer realm="%s/denied"`, server.URL), "", true},
+		{"没有realm", `Bearer service="registry.test"`, "", true},
+		{"Basic认证", `Basic realm="Registry Realm"`, "", true},
+		{"没有质询", "", "", true},
+	}
+	checker := NewOCIChecker()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := checker.fetchToken(context.Background(), tt.challenge, image)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("fetchToken(%q) 错误 = %v，期望返回错误: %v", tt.challenge, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("fetchToken(%q) = %q，期望 %q", tt.challenge, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseOCIImage(t *testing.T) {
+	tests := []struct {
+		url      string
+		wantTags string
+	}{
+		// Docker Hub镜像页面
+		{"https://hub.docker.com/_/nginx", "https://registry-1.docker.io/v2/library/nginx/tags/list"},
+		{"https://hub.docker.com/_/postgres/tags?name=16", "https://registry-1.docker.io/v2/library/postgres/tags/list"},
+		{"https://hub.docker.com/r/grafana/grafana", "https://registry-1.docker.io/v2/grafana/grafana/tags/list"},
+		{"https://hub.docker.com/r/bitnami/redis/tags", "https://registry-1.docker.io/v2/bitnami/redis/tags/list"},
+		// 镜像引用
+		{"docker://nginx", "https://registry-1.docker.io/v2/library/nginx/tags/list"},
+		{"docker://nginx:1.25-alpine", "https://registry-1.docker.io/v2/library/nginx/tags/list"},
+		{"oci://docker.io/bitnami/redis", "https://registry-1.docker.io/v2/bitnami/redis/tags/list"},
+		{"oci://index.docker.io/library/alpine", "https://registry-1.docker.io/v2/library/alpine/tags/list"},
+		{"oci://ghcr.io/owner/image:latest", "https://ghcr.io/v2/owner/image/tags/list"},
+		{"oci://quay.io/org/sub/image@sha256:0123abcd", "https://quay.io/v2/org/sub/image/tags/list"},
+		{"oci://localhost:5000/app", "http://localhost:5000/v2/app/tags/list"},
+		{"oci://127.0.0.1:5000/team/app:1.0", "http://127.0.0.1:5000/v2/team/app/tags/list"},
+		{"oci://https://localhost:5000/app", "https://localhost:5000/v2/app/tags/list"},
+		// 镜像仓库API地址
+		{"https://registry.example.com/v2/team/app/tags/list", "https://registry.example.com/v2/team/app/tags/list"},
+		{"http://localhost:5000/v2/app/tags/list", "http://localhost:5000/v2/app/tags/list"},
+	}
+	for _, tt := range tests {
+		image, err := parseOCIImage(tt.url)
+		if err != nil {
+			t.Errorf("parseOCIImage(%q) 返回错误: %v", tt.url, err)
+			continue
+		}
+		if got := image.tagsURL(); got != tt.wantTags {
+			t.Errorf("parseOCIImage(%q).tagsURL() = %q，期望 %q", tt.url, got, tt.wantTags)
+		}
+	}
+
+	for _, url := range []string{"oci://", "docker://", "https://registry.example.com/v2/"} {
+		if image, err := parseOCIImage(url); err == nil {
+			t.Errorf("parseOCIImage(%q) = %+v，期望返回错误", url, image)
+		}
+	}
+}
+
+func TestOCICheckerSupports(t *testing.T) {
+	checker := NewOCIChecker()
+	for url, want := range map[string]bool{
+		"oci://ghcr.io/owner/image":                          true,
+		"docker://nginx":                                     true,
+		"https://hub.docker.com/_/nginx":                     true,
+		"https://hub.docker.com/r/grafana/grafana":           true,
+		"https://registry.example.com/v2/team/app/tags/list": true,
+		"https://hub.docker.com/u/grafana":                   false,
+		"https://github.com/owner/repo":                      false,
+	} {
+		if got := checker.Supports(url); got != want {
+			t.Errorf("Supports(%q) = %v，期望 %v", url, got, want)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package checkers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"aur-update-checker/internal/checkers/common"
+	"aur-update-checker/internal/logger"
+	"aur-update-checker/internal/utils"
+
+	checkerInterfaces "aur-update-checker/internal/interfaces/checkers"
+)
+
+const (
+	// packagistMetadataURL Packagist的Composer v2元数据，只包含标签版本，开发分支在 ~dev.json 中
+	packagistMetadataURL = "https://repo.packagist.org/p2/%s.json"
+	// packagistUnset Composer v2压缩元数据中表示删除上一个版本字段的标记
+	packagistUnset = "__unset"
+)
+
+var (
+	// packagistNamePattern 匹配Packagist的包页面地址
+	packagistNamePattern = regexp.MustCompile(`(?:packagist\.org/packages|repo\.packagist\.org/p2?)/([a-z0-9_.-]+/[a-z0-9_.-]+?)(?:~dev)?(?:\.json)?(?:[/?#]|$)`)
+	// packagistStabilityPattern 匹配version_normalized中的稳定性后缀，如 1.0.0.0-beta2
+	packagistStabilityPattern = regexp.MustCompile(`(?i)-(dev|alpha|beta|rc|patch|pl|p)\.?(\d*)$`)
+)
+
+// packagistStabilityRank Composer的稳定性顺序，patch版本排在同一版本的正式版之后
+var packagistStabilityRank = map[string]int{"dev": 0, "alpha": 1, "beta": 2, "rc": 3, "": 4, "patch": 5, "pl": 5, "p": 5}
+
+// PackagistVersion Composer元数据中的版本信息
+type PackagistVersion struct {
+	Version           string `json:"version"`
+	VersionNormalized string `json:"version_normalized"`
+	Time              string `json:"time"`
+	Dist              struct {
+		Type   string `json:"type"`
+		URL    string `json:"url"`
+		Shasum string `json:"shasum"`
+	} `json:"dist"`
+}
+
+// PackagistChecker Packagist检查器
+type PackagistChecker struct {
+	*checkerInterfaces.BaseChecker
+	client *http.Client
+}
+
+// NewPackagistChecker 创建Packagist检查器
+func NewPackagistChecker() *PackagistChecker {
+	return &PackagistChecker{
+		BaseChecker: checkerInterfaces.NewBaseChecker("packagist"),
+		client:      &http.Client{},
+	}
+}
+
+// Supports 检查URL是否是Packagist的包地址
+func (c *PackagistChecker) Supports(url string) bool {
+	return packagistNamePattern.MatchString(url)
+}
+
+// Priority 专用于Packagist，优先级高于通用检查器
+func (c *PackagistChecker) Priority() int {
+	return 80
+}
+
+// Check 实现检查器接口，获取Composer包的最新版本
+func (c *PackagistChecker) Check(ctx context.Context, url, versionExtractKey string) (string, error) {
+	// 默认不检查测试版本
+	return c.CheckWithOption(ctx, url, versionExtractKey, 0)
+}
+
+// CheckWithOption 实现检查器接口，根据选项获取Composer包的最新版本
+func (c *PackagistChecker) CheckWithOption(ctx context.Context, url, versionExtractKey string, checkTestVersion int) (string, error) {
+	return c.CheckWithVersionRef(ctx, url, versionExtractKey, "", checkTestVersion)
+}
+
+// CheckWithVersionRef 带选项和版本引用地检查上游版本
+func (c *PackagistChecker) CheckWithVersionRef(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (string, error) {
+	result, err := c.CheckWithResult(ctx, url, versionExtractKey, versionRef, checkTestVersion)
+	if err != nil {
+		return "", err
+	}
+	return result.Version, nil
+}
+
+// CheckWithResult 实现检查器接口，返回Composer包的最新版本、发布时间和dist压缩包地址
+// 按version_normalized和Composer的稳定性顺序（dev < alpha < beta < RC < 正式版 < patch）选择
+func (c *PackagistChecker) CheckWithResult(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (*common.UpstreamCheckResult, error) {
+	name, err := c.packageName(url, versionExtractKey)
+	if err != nil {
+		logger.GlobalLogger.Errorf("[packagist] %v", err)
+		return nil, err
+	}
+	logger.GlobalLogger.Debugf("[packagist] 开始检查Composer包 - 名称: %s, 检查测试版本: %d", name, checkTestVersion)
+
+	versions, err := c.fetchVersions(ctx, name)
+	if err != nil {
+		logger.GlobalLogger.Errorf("[packagist] 获取包元数据失败: %v", err)
+		return nil, fmt.Errorf("获取包元数据失败: %v", err)
+	}
+
+	selected := c.selectVersion(versions, checkTestVersion)
+	if selected == nil {
+		logger.GlobalLogger.Errorf("[packagist] 包 %s 没有符合条件的版本", name)
+		return nil, fmt.Errorf("包 %s 在%d个版本中没有符合条件的版本", name, len(versions))
+	}
+
+	stability, _ := packagistStability(selected.VersionNormalized)
+	result := &common.UpstreamCheckResult{
+		Version:      c.BaseChecker.NormalizeVersionWithOption(selected.Version, checkTestVersion),
+		TagName:      selected.Version,
+		IsPrerelease: packagistStabilityRank[stability] < packagistStabilityRank[""],
+		ReleaseDate:  common.ParseReleaseDate(selected.Time),
+		SourceURL:    fmt.Sprintf("https://packagist.org/packages/%s#%s", name, selected.Version),
+	}
+	if selected.Dist.URL != "" {
+		result.Assets = []common.ReleaseAsset{{
+			Name:        fmt.Sprintf("%s-%s.%s", strings.ReplaceAll(name, "/", "-"), selected.Version, selected.Dist.Type),
+			DownloadURL: selected.Dist.URL,
+			Default:     true,
+		}}
+	}
+
+	logger.GlobalLogger.Infof("[packagist] 包 %s 的最新版本: %s", name, result.Version)
+	return result, nil
+}
+
+// packageName 从URL中提取 vendor/package 格式的包名，URL不是Packagist地址时使用versionExtractKey
+func (c *PackagistChecker) packageName(url, versionExtractKey string) (string, error) {
+	if matches := packagistNamePattern.FindStringSubmatch(strings.ToLower(url)); len(matches) > 1 {
+		return matches[1], nil
+	}
+	if strings.Count(versionExtractKey, "/") == 1 {
+		return strings.ToLower(versionExtractKey), nil
+	}
+	return "", fmt.Errorf("无法从URL中提取Composer包名: %s", url)
+}
+
+// fetchVersions 获取包的全部标签版本
+// Composer v2元数据是压缩格式，每个版本只包含与上一个版本不同的字段，需要依次合并才能得到完整的版本信息
+func (c *PackagistChecker) fetchVersions(ctx context.Context, name string) ([]PackagistVersion, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf(packagistMetadataURL, name), nil)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %v", err)
+	}
+	req.Header.Set("User-Agent", "aur-update-checker")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("请求失败: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("包 %s 不存在", name)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("请求失败，状态码: %d", resp.StatusCode)
+	}
+
+	var metadata struct {
+		Packages map[string][]map[string]json.RawMessage `json:"packages"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&metadata); err != nil {
+		return nil, fmt.Errorf("解析响应失败: %v", err)
+	}
+
+	merged := make(map[string]json.RawMessage)
+	var versions []PackagistVersion
+	for _, entry := range metadata.Packages[name] {
+		for key, value := range entry {
+			if string(value) == `"`+packagistUnset+`"` {
+				delete(merged, key)
+			} else {
+				merged[key] = value
+			}
+		}
+
+		data, err := json.Marshal(merged)
+		if err != nil {
+			return nil, fmt.Errorf("合并版本信息失败: %v", err)
+		}
+		var version PackagistVersion
+		if err := json.Unmarshal(data, &version); err != nil {
+			return nil, fmt.Errorf("解析版本信息失败: %v", err)
+		}
+		versions = append(versions, version)
+	}
+	return versions, nil
+}
+
+// selectVersion 选择最大的版本，不检查测试版本时跳过dev、alpha、beta和RC版本
+func (c *PackagistChecker) selectVersion(versions []PackagistVersion, checkTestVersion int) *PackagistVersion {
+	var selected *PackagistVersion
+	for i := range versions {
+		v := &versions[i]
+		if v.VersionNormalized == "" {
+			continue
+		}
+		stability, _ := packagistStability(v.VersionNormalized)
+		if checkTestVersion != 1 && packagistStabilityRank[stability] < packagistStabilityRank[""] {
+			continue
+		}
+		if selected == nil || comparePackagistVersions(v.VersionNormalized, selected.VersionNormalized) > 0 {
+			selected = v
+		}
+	}
+	return selected
+}
+
+// packagistStability 返回version_normalized的稳定性和稳定性序号，正式版的稳定性为空
+func packagistStability(normalized string) (string, string) {
+	matches := packagistStabilityPattern.FindStringSubmatch(normalized)
+	if matches == nil {
+		return "", ""
+	}
+	return strings.ToLower(matches[1]), matches[2]
+}
+
+// comparePackagistVersions 比较两个version_normalized，先比较数字部分，再比较稳定性和稳定性序号
+func comparePackagistVersions(v1, v2 string) int {
+	base1 := packagistStabilityPattern.ReplaceAllString(v1, "")
+	base2 := packagistStabilityPattern.ReplaceAllString(v2, "")
+	if result := utils.CompareVersionStrings(base1, base2); result != 0 {
+		return result
+	}
+
+	stability1, number1 := packagistStability(v1)
+	stability2, number2 := packagistStability(v2)
+	if rank1, rank2 := packagistStabilityRank[stability1], packagistStabilityRank[stability2]; rank1 != rank2 {
+		if rank1 > rank2 {
+			return 1
+		}
+		return -1
+	}
+	return utils.CompareVersionStrings(number1, number2)
+}
@@ -269,3 +269,12 @@ func (c *PlaywrightChecker) NormalizeVersionWithOption(version string, checkTest
 	// 调用BaseChecker的规范化方法
 	return c.BaseChecker.NormalizeVersionWithOption(version, checkTestVersion)
 }
+
+// CheckWithResult 实现检查器接口，该检查器只能获取版本号，结果中不包含发布时间
+func (c *PlaywrightChecker) CheckWithResult(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (*common.UpstreamCheckResult, error) {
+	version, err := c.CheckWithVersionRef(ctx, url, versionExtractKey, versionRef, checkTestVersion)
+	if err != nil {
+		return nil, err
+	}
+	return common.NewVersionResult(version, url), nil
+}
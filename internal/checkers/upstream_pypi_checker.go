@@ -1,6 +1,9 @@
 package checkers
 
 import (
+	"aur-update-checker/internal/checkers/common"
+	"aur-update-checker/internal/config"
+	versionProcessor "aur-update-checker/internal/checkers/version"
 	"aur-update-checker/internal/logger"
 	"context"
 	"encoding/json"
@@ -8,10 +11,15 @@ import (
 	"io"
 	"net/http"
 	"regexp"
+	"strings"
+	"time"
 
 	checkerInterfaces "aur-update-checker/internal/interfaces/checkers"
 )
 
+// OptionPythonVersion 软件包检查器选项：目标Python版本，如 3.12，设置后跳过requires_python不兼容的版本
+const OptionPythonVersion = "python_version"
+
 // PyPIPackage PyPI包信息
 type PyPIPackage struct {
 	Info struct {
@@ -25,16 +33,29 @@ type PyPIPackage struct {
 		PackageType string `json:"packagetype"`
 		URL         string `json:"url"`
 	} `json:"urls"`
-	Releases map[string][]struct {
-		PackageType string `json:"packagetype"`
-		URL         string `json:"url"`
-	} `json:"releases"`
+	Releases map[string][]PyPIReleaseFile `json:"releases"`
+}
+
+// PyPIReleaseFile 发布版本中的文件
+type PyPIReleaseFile struct {
+	Filename          string `json:"filename"`
+	PackageType       string `json:"packagetype"`
+	URL               string `json:"url"`
+	Size              int64  `json:"size"`
+	UploadTimeISO8601 string `json:"upload_time_iso_8601"`
+	RequiresPython    string `json:"requires_python"`
+	Yanked            bool   `json:"yanked"`
+	Digests           struct {
+		SHA256 string `json:"sha256"`
+	} `json:"digests"`
 }
 
 // PyPIChecker PyPI检查器
 type PyPIChecker struct {
 	*checkerInterfaces.BaseChecker
 	client *http.Client
+	// pythonVersion 全局配置的目标Python版本，软件包可以用 python_version 选项覆盖
+	pythonVersion string
 }
 
 // NewPyPIChecker 创建PyPI检查器
@@ -45,6 +66,11 @@ func NewPyPIChecker() *PyPIChecker {
 	}
 }
 
+// ApplySettings 应用检查器配置，从CustomParams中读取目标Python版本
+func (c *PyPIChecker) ApplySettings(settings config.CheckerSettings) {
+	c.pythonVersion = common.GetStringParam(settings.CustomParams, "python_version", "")
+}
+
 // Check 实现检查器接口，从PyPI获取包版本
 func (c *PyPIChecker) Check(ctx context.Context, url, versionExtractKey string) (string, error) {
 	// 默认不检查测试版本
@@ -59,30 +85,169 @@ func (c *PyPIChecker) CheckWithOption(ctx context.Context, url, versionExtractKe
 
 // CheckWithVersionRef 实现检查器接口，根据版本引用从PyPI获取包版本
 func (c *PyPIChecker) CheckWithVersionRef(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (string, error) {
+	result, err := c.CheckWithResult(ctx, url, versionExtractKey, versionRef, checkTestVersion)
+	if err != nil {
+		return "", err
+	}
+	return result.Version, nil
+}
+
+// CheckWithResult 实现检查器接口，返回PyPI包的版本、该版本文件的上传时间和下载地址
+// 未指定版本时按PEP 440对全部发布版本排序，跳过已撤回（yanked）的版本，配置了目标Python版本时跳过requires_python不兼容的版本
+func (c *PyPIChecker) CheckWithResult(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (*common.UpstreamCheckResult, error) {
 	// 从URL或versionExtractKey中提取包名
 	packageName, err := c.extractPackageName(url, versionExtractKey)
 	if err != nil {
 		logger.GlobalLogger.Errorf("[pypi] 提取PyPI包名失败: %v", err)
-		return "", fmt.Errorf("提取PyPI包名失败: %v", err)
+		return nil, fmt.Errorf("提取PyPI包名失败: %v", err)
+	}
+	// 版本提取关键字已用作包名时，不再作为版本号或提取规则
+	if versionExtractKey == packageName {
+		versionExtractKey = ""
 	}
 
 	// 获取PyPI包信息
 	packageInfo, err := c.fetchPackageInfo(ctx, packageName)
 	if err != nil {
 		logger.GlobalLogger.Errorf("[pypi] 获取PyPI包信息失败: %v", err)
-		return "", fmt.Errorf("获取PyPI包信息失败: %v", err)
+		return nil, fmt.Errorf("获取PyPI包信息失败: %v", err)
 	}
 
-	// 提取版本
-	version, err := c.extractVersionWithVersionRef(packageInfo, versionExtractKey, versionRef, checkTestVersion)
+	pythonVersion := common.CheckOptionsFromContext(ctx).Get(OptionPythonVersion, c.pythonVersion)
+	release, err := c.resolveRelease(packageInfo, versionExtractKey, versionRef, pythonVersion, checkTestVersion)
 	if err != nil {
-		logger.GlobalLogger.Errorf("[pypi] 提取版本失败: %v", err)
-		return "", fmt.Errorf("提取版本失败: %v", err)
+		logger.GlobalLogger.Errorf("[pypi] 选择发布版本失败: %v", err)
+		return nil, fmt.Errorf("选择发布版本失败: %v", err)
+	}
+
+	// 提取版本
+	version := release
+	if versionExtractKey != "" && versionExtractKey != release {
+		version, err = c.BaseChecker.ExtractVersionFromContent(release, versionExtractKey)
+		if err != nil {
+			logger.GlobalLogger.Errorf("[pypi] 提取版本失败: %v", err)
+			return nil, fmt.Errorf("提取版本失败: %v", err)
+		}
 	}
 
 	// 规范化版本号，移除平台特定信息
 	normalizedVersion := c.BaseChecker.NormalizeVersionWithOption(version, checkTestVersion)
-	return normalizedVersion, nil
+	isPrerelease := !versionProcessor.NewVersionComparator().IsStableVersion(release)
+	if parsed, err := versionProcessor.ParsePEP440Version(release); err == nil {
+		isPrerelease = parsed.IsPrerelease()
+		if version == release {
+			// 通用的规范化会去掉 .post1 等后缀，PEP 440版本号只去掉纪元部分（AUR中纪元单独保存）
+			normalizedVersion = release[strings.Index(release, "!")+1:]
+		}
+	}
+
+	return &common.UpstreamCheckResult{
+		Version:      normalizedVersion,
+		TagName:      release,
+		IsPrerelease: isPrerelease,
+		ReleaseDate:  c.releaseUploadTime(packageInfo, release),
+		SourceURL:    fmt.Sprintf("https://pypi.org/project/%s/%s/", packageName, release),
+		Assets:       c.releaseAssets(packageInfo.Releases[release]),
+	}, nil
+}
+
+// resolveRelease 返回要使用的发布版本号
+// 依次使用有效的versionRef、与发布版本号相同的versionExtractKey，否则按PEP 440选出最新的版本
+func (c *PyPIChecker) resolveRelease(packageInfo *PyPIPackage, versionExtractKey, versionRef, pythonVersion string, checkTestVersion int) (string, error) {
+	if versionRef != "" {
+		if _, ok := packageInfo.Releases[versionRef]; ok {
+			return versionRef, nil
+		}
+		// 如果versionRef不是有效的版本号，记录警告并使用最新版本
+		logger.GlobalLogger.Warnf("[pypi] 版本引用 %s 不是有效的版本号，将使用最新版本", versionRef)
+	}
+	if _, ok := packageInfo.Releases[versionExtractKey]; versionExtractKey != "" && ok {
+		return versionExtractKey, nil
+	}
+	return c.selectLatestRelease(packageInfo, pythonVersion, checkTestVersion)
+}
+
+// selectLatestRelease 按PEP 440选出最大的可用发布版本
+// 所有文件都已撤回或没有文件的版本不可用；不检查测试版本时跳过预发布和开发版本
+func (c *PyPIChecker) selectLatestRelease(packageInfo *PyPIPackage, pythonVersion string, checkTestVersion int) (string, error) {
+	var selected string
+	var selectedVersion *versionProcessor.PEP440Version
+	for release, files := range packageInfo.Releases {
+		parsed, err := versionProcessor.ParsePEP440Version(release)
+		if err != nil {
+			logger.GlobalLogger.Debugf("[pypi] 跳过不符合PEP 440的版本: %s", release)
+			continue
+		}
+		if checkTestVersion <= 0 && parsed.IsPrerelease() {
+			continue
+		}
+		if !c.hasInstallableFile(files, pythonVersion) {
+			continue
+		}
+		if selectedVersion == nil || parsed.Compare(selectedVersion) > 0 {
+			selected, selectedVersion = release, parsed
+		}
+	}
+
+	if selected == "" {
+		if len(packageInfo.Releases) == 0 && packageInfo.Info.Version != "" {
+			return packageInfo.Info.Version, nil
+		}
+		return "", fmt.Errorf("在%d个发布版本中没有符合条件的版本", len(packageInfo.Releases))
+	}
+	return selected, nil
+}
+
+// hasInstallableFile 检查发布版本中是否有未撤回且与目标Python版本兼容的文件
+func (c *PyPIChecker) hasInstallableFile(files []PyPIReleaseFile, pythonVersion string) bool {
+	for _, file := range files {
+		if file.Yanked {
+			continue
+		}
+		if pythonVersion == "" || file.RequiresPython == "" {
+			return true
+		}
+		specifiers, err := versionProcessor.ParsePEP440SpecifierSet(file.RequiresPython)
+		if err != nil {
+			// 无法解析的约束不作为排除依据
+			logger.GlobalLogger.Debugf("[pypi] 无法解析requires_python: %s", file.RequiresPython)
+			return true
+		}
+		if specifiers.Contains(pythonVersion) {
+			return true
+		}
+	}
+	return false
+}
+
+// releaseAssets 返回发布版本中未撤回的文件，源码包（sdist）作为默认附件
+func (c *PyPIChecker) releaseAssets(files []PyPIReleaseFile) []common.ReleaseAsset {
+	var assets []common.ReleaseAsset
+	for _, file := range files {
+		if file.Yanked {
+			continue
+		}
+		assets = append(assets, common.ReleaseAsset{
+			Name:        file.Filename,
+			DownloadURL: file.URL,
+			Size:        file.Size,
+			SHA256:      file.Digests.SHA256,
+			Default:     file.PackageType == "sdist",
+		})
+	}
+	return assets
+}
+
+// releaseUploadTime 返回发布版本中最早上传的文件的时间，即该版本的发布时间
+func (c *PyPIChecker) releaseUploadTime(packageInfo *PyPIPackage, release string) time.Time {
+	var earliest time.Time
+	for _, file := range packageInfo.Releases[release] {
+		uploaded := common.ParseReleaseDate(file.UploadTimeISO8601)
+		if !uploaded.IsZero() && (earliest.IsZero() || uploaded.Before(earliest)) {
+			earliest = uploaded
+		}
+	}
+	return earliest
 }
 
 // extractPackageName 从URL或versionExtractKey中提取包名
@@ -164,54 +329,3 @@ func (c *PyPIChecker) fetchPackageInfo(ctx context.Context, packageName string)
 
 	return &packageInfo, nil
 }
-
-// extractVersionWithVersionRef 根据版本引用从包信息中提取版本
-func (c *PyPIChecker) extractVersionWithVersionRef(packageInfo *PyPIPackage, versionExtractKey, versionRef string, checkTestVersion int) (string, error) {
-	// 如果提供了版本引用，尝试使用它
-	if versionRef != "" {
-		// 检查versionRef是否是有效的版本号
-		if _, ok := packageInfo.Releases[versionRef]; ok {
-			// 根据checkTestVersion参数决定是否检查测试版本
-			if checkTestVersion > 0 {
-				return versionRef, nil
-			}
-			// 如果不检查测试版本，需要过滤掉测试版本
-			return c.BaseChecker.NormalizeVersion(versionRef), nil
-		}
-		
-		// 如果versionRef不是有效的版本号，记录警告并使用最新版本
-		logger.GlobalLogger.Warnf("[pypi] 版本引用 %s 不是有效的版本号，将使用最新版本", versionRef)
-	}
-
-	// 如果versionExtractKey为空，使用最新版本
-	if versionExtractKey == "" {
-		// 根据checkTestVersion参数决定是否检查测试版本
-		if checkTestVersion > 0 {
-			return packageInfo.Info.Version, nil
-		}
-		// 如果不检查测试版本，需要过滤掉测试版本
-		return c.BaseChecker.NormalizeVersion(packageInfo.Info.Version), nil
-	}
-
-	// 检查versionExtractKey是否是版本号
-	if _, ok := packageInfo.Releases[versionExtractKey]; ok {
-		// 根据checkTestVersion参数决定是否检查测试版本
-		if checkTestVersion > 0 {
-			return versionExtractKey, nil
-		}
-		// 如果不检查测试版本，需要过滤掉测试版本
-		return c.BaseChecker.NormalizeVersion(versionExtractKey), nil
-	}
-
-	// 如果都不是，尝试使用versionExtractKey作为提取规则
-	version, err := c.BaseChecker.ExtractVersionFromContent(packageInfo.Info.Version, versionExtractKey)
-	if err != nil {
-		return "", err
-	}
-	// 根据checkTestVersion参数决定是否检查测试版本
-	if checkTestVersion > 0 {
-		return version, nil
-	}
-	// 如果不检查测试版本，需要过滤掉测试版本
-	return c.BaseChecker.NormalizeVersion(version), nil
-}
@@ -1,6 +1,7 @@
 package checkers
 
 import (
+	"aur-update-checker/internal/checkers/common"
 	"aur-update-checker/internal/logger"
 	"context"
 	"fmt"
@@ -279,3 +280,12 @@ func (c *RedirectChecker) CheckWithVersionRef(ctx context.Context, url, versionE
 	// 简单地调用CheckWithOption方法，忽略versionRef参数
 	return c.CheckWithOption(ctx, url, versionExtractKey, checkTestVersion)
 }
+
+// CheckWithResult 实现检查器接口，该检查器只能获取版本号，结果中不包含发布时间
+func (c *RedirectChecker) CheckWithResult(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (*common.UpstreamCheckResult, error) {
+	version, err := c.CheckWithVersionRef(ctx, url, versionExtractKey, versionRef, checkTestVersion)
+	if err != nil {
+		return nil, err
+	}
+	return common.NewVersionResult(version, url), nil
+}
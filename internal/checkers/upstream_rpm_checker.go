@@ -0,0 +1,308 @@
+package checkers
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"aur-update-checker/internal/checkers/common"
+	versionProcessor "aur-update-checker/internal/checkers/version"
+	"aur-update-checker/internal/logger"
+	"aur-update-checker/internal/utils"
+
+	checkerInterfaces "aur-update-checker/internal/interfaces/checkers"
+)
+
+const (
+	// defaultRpmArch RPM仓库默认检查的架构，noarch软件包总是参与比较
+	defaultRpmArch = "x86_64"
+	// rpmNoArch 与架构无关的软件包
+	rpmNoArch = "noarch"
+	// rpmRepomdPath 仓库元数据索引相对仓库根目录的路径
+	rpmRepomdPath = "repodata/repomd.xml"
+)
+
+// RpmRepomd repomd.xml仓库元数据索引
+type RpmRepomd struct {
+	Data []struct {
+		Type     string `xml:"type,attr"`
+		Location struct {
+			Href string `xml:"href,attr"`
+		} `xml:"location"`
+	} `xml:"data"`
+}
+
+// RpmPackage primary.xml中的软件包条目
+type RpmPackage struct {
+	Name    string `xml:"name"`
+	Arch    string `xml:"arch"`
+	Version struct {
+		Epoch string `xml:"epoch,attr"`
+		Ver   string `xml:"ver,attr"`
+		Rel   string `xml:"rel,attr"`
+	} `xml:"version"`
+	Checksum struct {
+		Type  string `xml:"type,attr"`
+		Value string `xml:",chardata"`
+	} `xml:"checksum"`
+	Time struct {
+		Build int64 `xml:"build,attr"`
+	} `xml:"time"`
+	Size struct {
+		Package int64 `xml:"package,attr"`
+	} `xml:"size"`
+	Location struct {
+		Base string `xml:"base,attr"`
+		Href string `xml:"href,attr"`
+	} `xml:"location"`
+}
+
+// EVR 返回 [epoch:]version-release 格式的完整版本号
+func (p *RpmPackage) EVR() string {
+	evr := p.Version.Ver + "-" + p.Version.Rel
+	if p.Version.Epoch != "" && p.Version.Epoch != "0" {
+		evr = p.Version.Epoch + ":" + evr
+	}
+	return evr
+}
+
+// RpmChecker RPM/YUM仓库检查器
+// 读取 repodata/repomd.xml 找到 primary.xml.gz，从中查找软件包的最新版本
+type RpmChecker struct {
+	*checkerInterfaces.BaseChecker
+	client *http.Client
+}
+
+// NewRpmChecker 创建RPM仓库检查器
+func NewRpmChecker() *RpmChecker {
+	return &RpmChecker{
+		BaseChecker: checkerInterfaces.NewBaseChecker("rpm"),
+		client:      &http.Client{},
+	}
+}
+
+// Supports 检查URL是否是RPM仓库元数据地址
+func (c *RpmChecker) Supports(url string) bool {
+	lower := strings.ToLower(url)
+	return strings.Contains(lower, "/repodata") || strings.HasSuffix(lower, "repomd.xml")
+}
+
+// Priority 仓库元数据中的版本很准确，优先级高于通用HTTP检查器
+func (c *RpmChecker) Priority() int {
+	return 65
+}
+
+// Check 实现检查器接口，获取RPM仓库中软件包的最新版本
+func (c *RpmChecker) Check(ctx context.Context, url, versionExtractKey string) (string, error) {
+	// 默认不检查测试版本
+	return c.CheckWithOption(ctx, url, versionExtractKey, 0)
+}
+
+// CheckWithOption 实现检查器接口，根据选项获取RPM仓库中软件包的最新版本
+func (c *RpmChecker) CheckWithOption(ctx context.Context, url, versionExtractKey string, checkTestVersion int) (string, error) {
+	return c.CheckWithVersionRef(ctx, url, versionExtractKey, "", checkTestVersion)
+}
+
+// CheckWithVersionRef 带选项和版本引用地检查上游版本
+func (c *RpmChecker) CheckWithVersionRef(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (string, error) {
+	result, err := c.CheckWithResult(ctx, url, versionExtractKey, versionRef, checkTestVersion)
+	if err != nil {
+		return "", err
+	}
+	return result.Version, nil
+}
+
+// CheckWithResult 实现检查器接口，返回软件包的最新版本、构建时间以及rpm文件的下载地址和校验和
+// 版本号为RPM的version字段，完整的 [epoch:]version-release 保存在TagName中
+// URL可以是仓库根地址（repodata目录的上级目录）或repomd.xml地址
+func (c *RpmChecker) CheckWithResult(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (*common.UpstreamCheckResult, error) {
+	options := common.CheckOptionsFromContext(ctx)
+	packageName := options.Get(OptionPackage, versionExtractKey)
+	if packageName == "" {
+		return nil, fmt.Errorf("未指定软件包名称，请在版本提取关键字或检查器选项 package 中设置")
+	}
+	arch := options.Get(OptionArch, defaultRpmArch)
+
+	repoURL := c.repoURL(url)
+	logger.GlobalLogger.Debugf("[rpm] 开始检查RPM仓库 - 仓库: %s, 软件包: %s, 架构: %s", repoURL, packageName, arch)
+
+	primaryURL, err := c.primaryURL(ctx, repoURL)
+	if err != nil {
+		logger.GlobalLogger.Errorf("[rpm] 获取仓库元数据索引失败: %v", err)
+		return nil, fmt.Errorf("获取仓库元数据索引失败: %v", err)
+	}
+
+	packages, err := c.fetchPackages(ctx, primaryURL, packageName, arch)
+	if err != nil {
+		logger.GlobalLogger.Errorf("[rpm] 获取软件包列表失败: %v", err)
+		return nil, fmt.Errorf("获取软件包列表失败: %v", err)
+	}
+
+	pkg := c.selectPackage(packages, checkTestVersion)
+	if pkg == nil {
+		logger.GlobalLogger.Errorf("[rpm] 仓库中未找到架构为 %s 的软件包 %s", arch, packageName)
+		return nil, fmt.Errorf("仓库中未找到架构为 %s 的软件包 %s", arch, packageName)
+	}
+
+	result := &common.UpstreamCheckResult{
+		Version:      c.BaseChecker.NormalizeVersionWithOption(pkg.Version.Ver, checkTestVersion),
+		TagName:      pkg.EVR(),
+		IsPrerelease: !versionProcessor.NewVersionComparator().IsStableVersion(pkg.Version.Ver),
+		SourceURL:    primaryURL,
+	}
+	if pkg.Time.Build > 0 {
+		result.ReleaseDate = time.Unix(pkg.Time.Build, 0).UTC()
+	}
+	if pkg.Location.Href != "" {
+		base := repoURL
+		if pkg.Location.Base != "" {
+			base = strings.TrimSuffix(pkg.Location.Base, "/")
+		}
+		asset := common.ReleaseAsset{
+			Name:        path.Base(pkg.Location.Href),
+			DownloadURL: base + "/" + strings.TrimPrefix(pkg.Location.Href, "/"),
+			Size:        pkg.Size.Package,
+			Default:     true,
+		}
+		if strings.EqualFold(pkg.Checksum.Type, "sha256") {
+			asset.SHA256 = strings.TrimSpace(pkg.Checksum.Value)
+		}
+		result.Assets = []common.ReleaseAsset{asset}
+	}
+
+	logger.GlobalLogger.Infof("[rpm] 软件包 %s 的最新版本: %s (%s)", packageName, result.Version, result.TagName)
+	return result, nil
+}
+
+// repoURL 返回仓库根地址，即repodata目录的上级目录
+func (c *RpmChecker) repoURL(url string) string {
+	url = strings.TrimSuffix(strings.TrimSpace(url), "/")
+	if i := strings.Index(url, "/repodata"); i >= 0 {
+		return url[:i]
+	}
+	return url
+}
+
+// primaryURL 读取repomd.xml，返回primary元数据的地址
+func (c *RpmChecker) primaryURL(ctx context.Context, repoURL string) (string, error) {
+	resp, err := c.get(ctx, repoURL+"/"+rpmRepomdPath)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var repomd RpmRepomd
+	if err := xml.NewDecoder(resp.Body).Decode(&repomd); err != nil {
+		return "", fmt.Errorf("解析repomd.xml失败: %v", err)
+	}
+
+	for _, data := range repomd.Data {
+		if data.Type == "primary" && data.Location.Href != "" {
+			return repoURL + "/" + strings.TrimPrefix(data.Location.Href, "/"), nil
+		}
+	}
+	return "", fmt.Errorf("repomd.xml中没有primary元数据")
+}
+
+// fetchPackages 下载primary元数据，返回指定名称和架构（包括noarch）的所有软件包
+// primary元数据可能有上百MB，因此逐个解码软件包条目，不读入内存
+// 支持未压缩和 gzip、zstd、xz、bzip2 压缩的元数据，按扩展名选择解压方式，扩展名未知时根据文件头判断
+func (c *RpmChecker) fetchPackages(ctx context.Context, primaryURL, packageName, arch string) ([]RpmPackage, error) {
+	resp, err := c.get(ctx, primaryURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	reader, err := common.NewDecompressReader(path.Base(primaryURL), resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("解压primary元数据失败: %v", err)
+	}
+	defer reader.Close()
+
+	var packages []RpmPackage
+	decoder := xml.NewDecoder(reader)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("解析primary元数据失败: %v", err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "package" {
+			continue
+		}
+
+		var pkg RpmPackage
+		if err := decoder.DecodeElement(&pkg, &start); err != nil {
+			return nil, fmt.Errorf("解析软件包条目失败: %v", err)
+		}
+		if pkg.Name == packageName && (pkg.Arch == arch || pkg.Arch == rpmNoArch) {
+			packages = append(packages, pkg)
+		}
+	}
+
+	return packages, nil
+}
+
+// selectPackage 按epoch、version、release依次比较，返回最新的软件包
+// 不检查测试版本时跳过测试版本
+func (c *RpmChecker) selectPackage(packages []RpmPackage, checkTestVersion int) *RpmPackage {
+	comparator := versionProcessor.NewVersionComparator()
+
+	var latest *RpmPackage
+	for i := range packages {
+		if checkTestVersion != 1 && !comparator.IsStableVersion(packages[i].Version.Ver) {
+			continue
+		}
+		if latest == nil || compareRpmPackages(comparator, &packages[i], latest) > 0 {
+			latest = &packages[i]
+		}
+	}
+	return latest
+}
+
+// compareRpmPackages 比较两个软件包的版本，epoch按数值比较，version使用版本比较器
+// release中的发行版标识（如 el9、fc40）会被版本比较器的标准化步骤当作平台信息去掉，因此直接按组件比较
+func compareRpmPackages(comparator *versionProcessor.VersionComparator, a, b *RpmPackage) int {
+	epochA, _ := strconv.Atoi(a.Version.Epoch)
+	epochB, _ := strconv.Atoi(b.Version.Epoch)
+	if epochA != epochB {
+		if epochA > epochB {
+			return 1
+		}
+		return -1
+	}
+	if result := comparator.CompareVersions(a.Version.Ver, b.Version.Ver); result != 0 {
+		return result
+	}
+	return utils.CompareVersionStrings(a.Version.Rel, b.Version.Rel)
+}
+
+// get 发送GET请求，状态码不是200时返回错误
+func (c *RpmChecker) get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %v", err)
+	}
+	req.Header.Set("User-Agent", "aur-update-checker")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("请求失败: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("请求 %s 失败，状态码: %d", url, resp.StatusCode)
+	}
+	return resp, nil
+}
@@ -0,0 +1,230 @@
+package checkers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+
+	"aur-update-checker/internal/checkers/common"
+	"aur-update-checker/internal/logger"
+
+	checkerInterfaces "aur-update-checker/internal/interfaces/checkers"
+)
+
+const (
+	// rubyGemsVersionsURL RubyGems的gem版本列表API，已撤回的版本不会出现在列表中
+	rubyGemsVersionsURL = "https://rubygems.org/api/v1/versions/%s.json"
+	// rubyGemsDownloadURL gem包的下载地址
+	rubyGemsDownloadURL = "https://rubygems.org/downloads/%s-%s.gem"
+	// rubyGemsDefaultPlatform 纯Ruby实现的gem所在的平台
+	rubyGemsDefaultPlatform = "ruby"
+)
+
+var (
+	// rubyGemsNamePattern 匹配RubyGems的gem页面地址
+	rubyGemsNamePattern = regexp.MustCompile(`rubygems\.org/(?:gems|api/v1/versions)/([A-Za-z0-9_.-]+?)(?:\.json)?(?:[/?#]|$)`)
+	// rubyGemsSegmentPattern 拆分gem版本号，数字和字母分别作为一段，如 1.0.0.rc1 拆分为 1、0、0、rc、1
+	rubyGemsSegmentPattern = regexp.MustCompile(`[0-9]+|[A-Za-z]+`)
+)
+
+// RubyGemsVersion RubyGems版本列表API中的版本信息
+type RubyGemsVersion struct {
+	Number     string `json:"number"`
+	Platform   string `json:"platform"`
+	Prerelease bool   `json:"prerelease"`
+	CreatedAt  string `json:"created_at"`
+	SHA        string `json:"sha"`
+}
+
+// RubyGemsChecker RubyGems检查器
+type RubyGemsChecker struct {
+	*checkerInterfaces.BaseChecker
+	client *http.Client
+}
+
+// NewRubyGemsChecker 创建RubyGems检查器
+func NewRubyGemsChecker() *RubyGemsChecker {
+	return &RubyGemsChecker{
+		BaseChecker: checkerInterfaces.NewBaseChecker("rubygems"),
+		client:      &http.Client{},
+	}
+}
+
+// Supports 检查URL是否是RubyGems的gem地址
+func (c *RubyGemsChecker) Supports(url string) bool {
+	return rubyGemsNamePattern.MatchString(url)
+}
+
+// Priority 专用于RubyGems，优先级高于通用检查器
+func (c *RubyGemsChecker) Priority() int {
+	return 80
+}
+
+// Check 实现检查器接口，获取gem的最新版本
+func (c *RubyGemsChecker) Check(ctx context.Context, url, versionExtractKey string) (string, error) {
+	// 默认不检查测试版本
+	return c.CheckWithOption(ctx, url, versionExtractKey, 0)
+}
+
+// CheckWithOption 实现检查器接口，根据选项获取gem的最新版本
+func (c *RubyGemsChecker) CheckWithOption(ctx context.Context, url, versionExtractKey string, checkTestVersion int) (string, error) {
+	return c.CheckWithVersionRef(ctx, url, versionExtractKey, "", checkTestVersion)
+}
+
+// CheckWithVersionRef 带选项和版本引用地检查上游版本
+func (c *RubyGemsChecker) CheckWithVersionRef(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (string, error) {
+	result, err := c.CheckWithResult(ctx, url, versionExtractKey, versionRef, checkTestVersion)
+	if err != nil {
+		return "", err
+	}
+	return result.Version, nil
+}
+
+// CheckWithResult 实现检查器接口，返回gem的最新版本、发布时间以及.gem包的下载地址和SHA256
+// 只比较ruby平台的版本，预编译的平台版本（如 x86_64-linux、java）与之版本号相同
+func (c *RubyGemsChecker) CheckWithResult(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (*common.UpstreamCheckResult, error) {
+	name, err := c.gemName(url, versionExtractKey)
+	if err != nil {
+		logger.GlobalLogger.Errorf("[rubygems] %v", err)
+		return nil, err
+	}
+	logger.GlobalLogger.Debugf("[rubygems] 开始检查gem - 名称: %s, 检查测试版本: %d", name, checkTestVersion)
+
+	versions, err := c.fetchVersions(ctx, name)
+	if err != nil {
+		logger.GlobalLogger.Errorf("[rubygems] 获取gem版本列表失败: %v", err)
+		return nil, fmt.Errorf("获取gem版本列表失败: %v", err)
+	}
+
+	selected := c.selectVersion(versions, checkTestVersion)
+	if selected == nil {
+		logger.GlobalLogger.Errorf("[rubygems] gem %s 没有符合条件的版本", name)
+		return nil, fmt.Errorf("gem %s 在%d个版本中没有符合条件的版本", name, len(versions))
+	}
+
+	result := &common.UpstreamCheckResult{
+		Version:      c.BaseChecker.NormalizeVersionWithOption(selected.Number, checkTestVersion),
+		TagName:      selected.Number,
+		IsPrerelease: selected.Prerelease,
+		ReleaseDate:  common.ParseReleaseDate(selected.CreatedAt),
+		SourceURL:    fmt.Sprintf("https://rubygems.org/gems/%s/versions/%s", name, selected.Number),
+		Assets: []common.ReleaseAsset{{
+			Name:        fmt.Sprintf("%s-%s.gem", name, selected.Number),
+			DownloadURL: fmt.Sprintf(rubyGemsDownloadURL, name, selected.Number),
+			SHA256:      selected.SHA,
+			Default:     true,
+		}},
+	}
+
+	logger.GlobalLogger.Infof("[rubygems] gem %s 的最新版本: %s", name, result.Version)
+	return result, nil
+}
+
+// gemName 从URL中提取gem名称，URL不是RubyGems地址时使用versionExtractKey
+func (c *RubyGemsChecker) gemName(url, versionExtractKey string) (string, error) {
+	if matches := rubyGemsNamePattern.FindStringSubmatch(url); len(matches) > 1 {
+		return matches[1], nil
+	}
+	if versionExtractKey != "" {
+		return versionExtractKey, nil
+	}
+	return "", fmt.Errorf("无法从URL中提取gem名称: %s", url)
+}
+
+// fetchVersions 获取gem的全部版本
+func (c *RubyGemsChecker) fetchVersions(ctx context.Context, name string) ([]RubyGemsVersion, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf(rubyGemsVersionsURL, url.PathEscape(name)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %v", err)
+	}
+	req.Header.Set("User-Agent", "aur-update-checker")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("请求失败: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("gem %s 不存在", name)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("请求失败，状态码: %d", resp.StatusCode)
+	}
+
+	var versions []RubyGemsVersion
+	if err := json.NewDecoder(resp.Body).Decode(&versions); err != nil {
+		return nil, fmt.Errorf("解析响应失败: %v", err)
+	}
+	return versions, nil
+}
+
+// selectVersion 选择ruby平台的最大版本，没有ruby平台的版本时使用全部平台
+func (c *RubyGemsChecker) selectVersion(versions []RubyGemsVersion, checkTestVersion int) *RubyGemsVersion {
+	hasRubyPlatform := false
+	for _, v := range versions {
+		if v.Platform == rubyGemsDefaultPlatform {
+			hasRubyPlatform = true
+			break
+		}
+	}
+
+	var selected *RubyGemsVersion
+	for i := range versions {
+		v := &versions[i]
+		if hasRubyPlatform && v.Platform != rubyGemsDefaultPlatform {
+			continue
+		}
+		if checkTestVersion != 1 && v.Prerelease {
+			continue
+		}
+		if selected == nil || compareGemVersions(v.Number, selected.Number) > 0 {
+			selected = v
+		}
+	}
+	return selected
+}
+
+// compareGemVersions 按Gem::Version的规则比较版本号，返回1表示v1大于v2，0表示相等，-1表示v1小于v2
+// 缺少的段视为0，字母段小于数字段，因此 1.0.0.rc1 < 1.0.0 == 1.0
+func compareGemVersions(v1, v2 string) int {
+	segments1 := rubyGemsSegmentPattern.FindAllString(v1, -1)
+	segments2 := rubyGemsSegmentPattern.FindAllString(v2, -1)
+	for i := 0; i < len(segments1) || i < len(segments2); i++ {
+		s1, s2 := "0", "0"
+		if i < len(segments1) {
+			s1 = segments1[i]
+		}
+		if i < len(segments2) {
+			s2 = segments2[i]
+		}
+		n1, err1 := strconv.Atoi(s1)
+		n2, err2 := strconv.Atoi(s2)
+		switch {
+		case err1 == nil && err2 == nil:
+			if n1 != n2 {
+				if n1 > n2 {
+					return 1
+				}
+				return -1
+			}
+		case err1 == nil:
+			return 1
+		case err2 == nil:
+			return -1
+		default:
+			if s1 != s2 {
+				if s1 > s2 {
+					return 1
+				}
+				return -1
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,262 @@
+package checkers
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"aur-update-checker/internal/checkers/common"
+	versionProcessor "aur-update-checker/internal/checkers/version"
+	"aur-update-checker/internal/logger"
+
+	checkerInterfaces "aur-update-checker/internal/interfaces/checkers"
+)
+
+const (
+	// sourceForgeRSSURL 项目文件RSS地址，limit为返回的最大文件数
+	sourceForgeRSSURL = "https://sourceforge.net/projects/%s/rss?path=%s&limit=100"
+	// sourceForgeDownloadURL 文件的直接下载地址，会重定向到镜像
+	sourceForgeDownloadURL = "https://downloads.sourceforge.net/project/%s%s"
+)
+
+var (
+	// sourceForgeProjectPatterns 从各种SourceForge地址中提取项目名称
+	sourceForgeProjectPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`sourceforge\.net/projects?/([^/?#]+)`),
+		regexp.MustCompile(`sourceforge\.net/p/([^/?#]+)`),
+		regexp.MustCompile(`^https?://([^./]+)\.sourceforge\.(?:net|io)`),
+	}
+	// sourceForgeFilesPathPattern 匹配项目文件页面地址中的目录路径
+	sourceForgeFilesPathPattern = regexp.MustCompile(`sourceforge\.net/projects/[^/?#]+/files(/[^?#]*)`)
+)
+
+// SourceForgeRSS SourceForge项目文件RSS
+type SourceForgeRSS struct {
+	Items []SourceForgeRSSItem `xml:"channel>item"`
+}
+
+// SourceForgeRSSItem RSS中的文件条目，标题是文件在项目中的路径
+type SourceForgeRSSItem struct {
+	Title   string `xml:"title"`
+	Link    string `xml:"link"`
+	PubDate string `xml:"pubDate"`
+	Content struct {
+		FileSize int64 `xml:"filesize,attr"`
+	} `xml:"content"` // media:content，包含文件大小
+}
+
+// SourceForgeChecker SourceForge项目RSS检查器
+type SourceForgeChecker struct {
+	*checkerInterfaces.BaseChecker
+	client *http.Client
+}
+
+// NewSourceForgeChecker 创建SourceForge检查器
+func NewSourceForgeChecker() *SourceForgeChecker {
+	return &SourceForgeChecker{
+		BaseChecker: checkerInterfaces.NewBaseChecker("sourceforge"),
+		client:      &http.Client{},
+	}
+}
+
+// Supports 检查URL是否是SourceForge项目地址
+func (c *SourceForgeChecker) Supports(url string) bool {
+	_, err := sourceForgeProject(url)
+	return err == nil
+}
+
+// Priority 专用于SourceForge，优先级高于通用检查器
+func (c *SourceForgeChecker) Priority() int {
+	return 70
+}
+
+// Check 实现检查器接口，获取SourceForge项目的最新版本
+func (c *SourceForgeChecker) Check(ctx context.Context, url, versionExtractKey string) (string, error) {
+	// 默认不检查测试版本
+	return c.CheckWithOption(ctx, url, versionExtractKey, 0)
+}
+
+// CheckWithOption 实现检查器接口，根据选项获取SourceForge项目的最新版本
+func (c *SourceForgeChecker) CheckWithOption(ctx context.Context, url, versionExtractKey string, checkTestVersion int) (string, error) {
+	return c.CheckWithVersionRef(ctx, url, versionExtractKey, "", checkTestVersion)
+}
+
+// CheckWithVersionRef 带选项和版本引用地检查上游版本
+func (c *SourceForgeChecker) CheckWithVersionRef(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (string, error) {
+	result, err := c.CheckWithResult(ctx, url, versionExtractKey, versionRef, checkTestVersion)
+	if err != nil {
+		return "", err
+	}
+	return result.Version, nil
+}
+
+// CheckWithResult 实现检查器接口，从项目文件RSS中选出版本号最大的文件，返回版本、发布时间和直接下载地址
+// versionExtractKey 是匹配文件路径的正则表达式，有捕获组时使用第一个捕获组作为版本号，
+// 否则从文件名（文件名中没有时从目录名）中提取版本号；为空时使用全部文件
+func (c *SourceForgeChecker) CheckWithResult(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (*common.UpstreamCheckResult, error) {
+	project, err := sourceForgeProject(url)
+	if err != nil {
+		logger.GlobalLogger.Errorf("[sourceforge] %v", err)
+		return nil, err
+	}
+	filesPath := sourceForgeFilesPath(url)
+	logger.GlobalLogger.Debugf("[sourceforge] 开始检查项目 - 项目: %s, 目录: %s, 文件过滤: %s", project, filesPath, versionExtractKey)
+
+	var filter *regexp.Regexp
+	if versionExtractKey != "" {
+		re, err := regexp.Compile(versionExtractKey)
+		if err != nil {
+			logger.GlobalLogger.Errorf("[sourceforge] 编译文件过滤正则表达式失败: %v", err)
+			return nil, fmt.Errorf("编译文件过滤正则表达式失败: %v", err)
+		}
+		filter = re
+	}
+
+	items, err := c.fetchItems(ctx, project, filesPath)
+	if err != nil {
+		logger.GlobalLogger.Errorf("[sourceforge] 获取项目文件RSS失败: %v", err)
+		return nil, fmt.Errorf("获取项目文件RSS失败: %v", err)
+	}
+
+	result, err := c.selectFile(project, items, filter, checkTestVersion)
+	if err != nil {
+		logger.GlobalLogger.Errorf("[sourceforge] %v", err)
+		return nil, err
+	}
+
+	logger.GlobalLogger.Infof("[sourceforge] 从%d个文件中选择 %s，版本: %s", len(items), result.TagName, result.Version)
+	return result, nil
+}
+
+// selectFile 按过滤规则筛选文件，返回版本号最大的文件，版本相同时选择发布时间最新的文件
+func (c *SourceForgeChecker) selectFile(project string, items []SourceForgeRSSItem, filter *regexp.Regexp, checkTestVersion int) (*common.UpstreamCheckResult, error) {
+	comparator := versionProcessor.NewVersionComparator()
+
+	var candidates []*common.UpstreamCheckResult
+	for _, item := range items {
+		filePath := strings.TrimSpace(item.Title)
+		version := ""
+		if filter != nil {
+			matches := filter.FindStringSubmatch(filePath)
+			if matches == nil {
+				continue
+			}
+			if len(matches) > 1 {
+				version = matches[1]
+			}
+		}
+		if version == "" {
+			version = common.ExtractVersionFromString(path.Base(filePath))
+		}
+		if version == "" {
+			version = common.ExtractVersionFromString(path.Dir(filePath))
+		}
+		if version == "" {
+			continue
+		}
+
+		version = c.BaseChecker.NormalizeVersionWithOption(version, checkTestVersion)
+		stable := comparator.IsStableVersion(version)
+		if checkTestVersion != 1 && !stable {
+			continue
+		}
+
+		candidates = append(candidates, &common.UpstreamCheckResult{
+			Version:      version,
+			TagName:      filePath,
+			IsPrerelease: !stable,
+			ReleaseDate:  common.ParseReleaseDate(item.PubDate),
+			SourceURL:    item.Link,
+			Assets: []common.ReleaseAsset{{
+				Name:        path.Base(filePath),
+				DownloadURL: sourceForgeDownloadLink(project, filePath),
+				Size:        item.Content.FileSize,
+				Default:     true,
+			}},
+		})
+	}
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("在%d个文件中未找到符合条件的版本", len(items))
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if result := comparator.CompareVersions(candidates[i].Version, candidates[j].Version); result != 0 {
+			return result > 0
+		}
+		return candidates[i].ReleaseDate.After(candidates[j].ReleaseDate)
+	})
+	return candidates[0], nil
+}
+
+// fetchItems 获取项目指定目录下的文件RSS条目
+func (c *SourceForgeChecker) fetchItems(ctx context.Context, project, filesPath string) ([]SourceForgeRSSItem, error) {
+	rssURL := fmt.Sprintf(sourceForgeRSSURL, url.PathEscape(project), url.QueryEscape(filesPath))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", rssURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %v", err)
+	}
+	req.Header.Set("User-Agent", "aur-update-checker")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("请求失败: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("请求失败，状态码: %d", resp.StatusCode)
+	}
+
+	var rss SourceForgeRSS
+	if err := xml.NewDecoder(resp.Body).Decode(&rss); err != nil {
+		return nil, fmt.Errorf("解析RSS失败: %v", err)
+	}
+	return rss.Items, nil
+}
+
+// sourceForgeProject 从SourceForge地址中提取项目名称
+func sourceForgeProject(rawURL string) (string, error) {
+	for _, pattern := range sourceForgeProjectPatterns {
+		if matches := pattern.FindStringSubmatch(rawURL); len(matches) > 1 {
+			switch matches[1] {
+			case "www", "downloads", "master", "sourceforge":
+				continue
+			}
+			return matches[1], nil
+		}
+	}
+	return "", fmt.Errorf("无法从URL中提取SourceForge项目名称: %s", rawURL)
+}
+
+// sourceForgeFilesPath 从项目文件页面地址中提取目录路径，如 /projects/foo/files/linux/ 返回 /linux，默认为根目录
+func sourceForgeFilesPath(rawURL string) string {
+	matches := sourceForgeFilesPathPattern.FindStringSubmatch(rawURL)
+	if len(matches) < 2 {
+		return "/"
+	}
+	filesPath := strings.TrimSuffix(strings.TrimSuffix(matches[1], "/download"), "/")
+	if unescaped, err := url.PathUnescape(filesPath); err == nil {
+		filesPath = unescaped
+	}
+	if filesPath == "" {
+		return "/"
+	}
+	return filesPath
+}
+
+// sourceForgeDownloadLink 返回文件的直接下载地址，路径中的每一段都需要转义
+func sourceForgeDownloadLink(project, filePath string) string {
+	segments := strings.Split(filePath, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return fmt.Sprintf(sourceForgeDownloadURL, url.PathEscape(project), strings.Join(segments, "/"))
+}
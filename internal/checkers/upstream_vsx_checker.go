@@ -0,0 +1,234 @@
+package checkers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	neturl "net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver"
+
+	"aur-update-checker/internal/checkers/common"
+	"aur-update-checker/internal/config"
+	"aur-update-checker/internal/logger"
+
+	checkerInterfaces "aur-update-checker/internal/interfaces/checkers"
+)
+
+const (
+	// OptionTargetPlatform 软件包检查器选项：扩展的目标平台，如 linux-x64、linux-arm64，默认使用通用版本
+	OptionTargetPlatform = "target_platform"
+
+	// defaultVSXBaseURL 默认的Open VSX地址
+	defaultVSXBaseURL = "https://open-vsx.org"
+	// vsxMaxVersionLookups 最新版本是预发布版本时，查找正式版本最多请求的版本数
+	vsxMaxVersionLookups = 10
+)
+
+var (
+	// vsxExtensionPagePattern 匹配Open VSX的扩展页面地址，如 https://open-vsx.org/extension/redhat/java
+	vsxExtensionPagePattern = regexp.MustCompile(`^(https?://[^/]+)/extension/([^/?#]+)/([^/?#]+)`)
+	// vsxMarketplacePattern 匹配VS Code扩展市场的扩展页面地址，如 https://marketplace.visualstudio.com/items?itemName=redhat.java
+	vsxMarketplacePattern = regexp.MustCompile(`marketplace\.visualstudio\.com/items\?(?:.*&)?itemName=([^&#.]+)\.([^&#]+)`)
+	// vsxExtensionIDPattern 匹配 publisher.extension 格式的扩展ID
+	vsxExtensionIDPattern = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9-]*)\.([A-Za-z0-9][A-Za-z0-9._-]*)$`)
+)
+
+// VSXExtension Open VSX扩展API的响应
+type VSXExtension struct {
+	Namespace      string            `json:"namespace"`
+	Name           string            `json:"name"`
+	Version        string            `json:"version"`
+	TargetPlatform string            `json:"targetPlatform"`
+	PreRelease     bool              `json:"preRelease"`
+	Timestamp      string            `json:"timestamp"`
+	Files          map[string]string `json:"files"`
+	// AllVersions 版本号到扩展API地址的映射，包括 latest、pre-release 等别名
+	AllVersions map[string]string `json:"allVersions"`
+	Error       string            `json:"error"`
+}
+
+// VSXChecker Open VSX扩展检查器
+type VSXChecker struct {
+	*checkerInterfaces.BaseChecker
+	client  *http.Client
+	baseURL string
+}
+
+// NewVSXChecker 创建Open VSX扩展检查器
+func NewVSXChecker() *VSXChecker {
+	return &VSXChecker{
+		BaseChecker: checkerInterfaces.NewBaseChecker("vsx"),
+		client:      &http.Client{},
+		baseURL:     defaultVSXBaseURL,
+	}
+}
+
+// ApplySettings 应用检查器配置，从CustomParams中读取Open VSX地址，用于自建的Open VSX服务
+func (c *VSXChecker) ApplySettings(settings config.CheckerSettings) {
+	if baseURL := common.GetStringParam(settings.CustomParams, "base_url", ""); baseURL != "" {
+		c.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// Supports 检查URL是否是Open VSX或VS Code扩展市场的扩展地址
+func (c *VSXChecker) Supports(url string) bool {
+	if vsxMarketplacePattern.MatchString(url) {
+		return true
+	}
+	matches := vsxExtensionPagePattern.FindStringSubmatch(url)
+	return len(matches) > 1 && (strings.Contains(matches[1], "open-vsx.org") || matches[1] == c.baseURL)
+}
+
+// Priority 专用于Open VSX，优先级高于通用检查器
+func (c *VSXChecker) Priority() int {
+	return 80
+}
+
+// Check 实现检查器接口，获取扩展的最新版本
+func (c *VSXChecker) Check(ctx context.Context, url, versionExtractKey string) (string, error) {
+	// 默认不检查测试版本
+	return c.CheckWithOption(ctx, url, versionExtractKey, 0)
+}
+
+// CheckWithOption 实现检查器接口，根据选项获取扩展的最新版本
+func (c *VSXChecker) CheckWithOption(ctx context.Context, url, versionExtractKey string, checkTestVersion int) (string, error) {
+	return c.CheckWithVersionRef(ctx, url, versionExtractKey, "", checkTestVersion)
+}
+
+// CheckWithVersionRef 带选项和版本引用地检查上游版本
+func (c *VSXChecker) CheckWithVersionRef(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (string, error) {
+	result, err := c.CheckWithResult(ctx, url, versionExtractKey, versionRef, checkTestVersion)
+	if err != nil {
+		return "", err
+	}
+	return result.Version, nil
+}
+
+// CheckWithResult 实现检查器接口，返回扩展的最新版本、预发布标记、发布时间和VSIX下载地址
+// VS Code扩展市场的地址同样从Open VSX获取版本；不检查测试版本时，最新版本是预发布版本则向前查找最新的正式版本
+func (c *VSXChecker) CheckWithResult(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (*common.UpstreamCheckResult, error) {
+	baseURL, namespace, name, err := c.parseExtension(url, versionExtractKey)
+	if err != nil {
+		logger.GlobalLogger.Errorf("[vsx] %v", err)
+		return nil, err
+	}
+	extensionURL := fmt.Sprintf("%s/api/%s/%s", baseURL, neturl.PathEscape(namespace), neturl.PathEscape(name))
+	if target := common.CheckOptionsFromContext(ctx).Get(OptionTargetPlatform, ""); target != "" {
+		extensionURL += "/" + neturl.PathEscape(target)
+	}
+	logger.GlobalLogger.Debugf("[vsx] 开始检查扩展 - 扩展: %s.%s, 地址: %s", namespace, name, extensionURL)
+
+	extension, err := c.fetchExtension(ctx, extensionURL)
+	if err != nil {
+		logger.GlobalLogger.Errorf("[vsx] 获取扩展信息失败: %v", err)
+		return nil, fmt.Errorf("获取扩展信息失败: %v", err)
+	}
+
+	if extension.PreRelease && checkTestVersion != 1 {
+		stable, err := c.findStableVersion(ctx, extensionURL, extension)
+		if err != nil {
+			logger.GlobalLogger.Errorf("[vsx] %v", err)
+			return nil, err
+		}
+		extension = stable
+	}
+
+	result := &common.UpstreamCheckResult{
+		Version:      c.BaseChecker.NormalizeVersionWithOption(extension.Version, checkTestVersion),
+		TagName:      extension.Version,
+		IsPrerelease: extension.PreRelease,
+		ReleaseDate:  common.ParseReleaseDate(extension.Timestamp),
+		SourceURL:    fmt.Sprintf("%s/extension/%s/%s/%s", baseURL, namespace, name, extension.Version),
+	}
+	if download := extension.Files["download"]; download != "" {
+		result.Assets = []common.ReleaseAsset{{
+			Name:        download[strings.LastIndex(download, "/")+1:],
+			DownloadURL: download,
+			Default:     true,
+		}}
+	}
+
+	logger.GlobalLogger.Infof("[vsx] 扩展 %s.%s 的最新版本: %s", namespace, name, result.Version)
+	return result, nil
+}
+
+// parseExtension 解析扩展所在的Open VSX地址、发布者（namespace）和扩展名
+// 支持Open VSX扩展页面、VS Code扩展市场页面，以及URL或versionExtractKey中的 publisher.extension
+func (c *VSXChecker) parseExtension(url, versionExtractKey string) (string, string, string, error) {
+	url = strings.TrimSpace(url)
+	if matches := vsxExtensionPagePattern.FindStringSubmatch(url); len(matches) > 3 {
+		return matches[1], matches[2], matches[3], nil
+	}
+	if matches := vsxMarketplacePattern.FindStringSubmatch(url); len(matches) > 2 {
+		return c.baseURL, matches[1], matches[2], nil
+	}
+	for _, id := range []string{url, strings.TrimSpace(versionExtractKey)} {
+		if matches := vsxExtensionIDPattern.FindStringSubmatch(id); len(matches) > 2 {
+			return c.baseURL, matches[1], matches[2], nil
+		}
+	}
+	return "", "", "", fmt.Errorf("无法解析扩展ID，请使用 publisher.extension 格式或Open VSX扩展页面地址: %s", url)
+}
+
+// findStableVersion 按版本号从大到小查找最新的正式版本
+func (c *VSXChecker) findStableVersion(ctx context.Context, extensionURL string, latest *VSXExtension) (*VSXExtension, error) {
+	var versions []*semver.Version
+	for key := range latest.AllVersions {
+		// 跳过 latest、pre-release 等别名
+		if parsed, err := semver.NewVersion(key); err == nil && key != latest.Version && parsed.Prerelease() == "" {
+			versions = append(versions, parsed)
+		}
+	}
+	sort.Sort(sort.Reverse(semver.Collection(versions)))
+
+	for i, version := range versions {
+		if i >= vsxMaxVersionLookups {
+			break
+		}
+		extension, err := c.fetchExtension(ctx, extensionURL+"/"+version.Original())
+		if err != nil {
+			return nil, fmt.Errorf("获取版本 %s 的信息失败: %v", version.Original(), err)
+		}
+		if !extension.PreRelease {
+			return extension, nil
+		}
+	}
+	return nil, fmt.Errorf("扩展 %s.%s 在最近%d个版本中没有正式版本", latest.Namespace, latest.Name, min(len(versions), vsxMaxVersionLookups))
+}
+
+// fetchExtension 获取扩展信息
+func (c *VSXChecker) fetchExtension(ctx context.Context, apiURL string) (*VSXExtension, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %v", err)
+	}
+	req.Header.Set("User-Agent", "aur-update-checker")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("请求失败: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var extension VSXExtension
+	decodeErr := json.NewDecoder(resp.Body).Decode(&extension)
+	if resp.StatusCode != http.StatusOK {
+		if decodeErr == nil && extension.Error != "" {
+			return nil, fmt.Errorf("请求失败，状态码: %d，错误: %s", resp.StatusCode, extension.Error)
+		}
+		return nil, fmt.Errorf("请求失败，状态码: %d", resp.StatusCode)
+	}
+	if decodeErr != nil {
+		return nil, fmt.Errorf("解析响应失败: %v", decodeErr)
+	}
+	if extension.Version == "" {
+		return nil, fmt.Errorf("响应中没有版本信息")
+	}
+	return &extension, nil
+}
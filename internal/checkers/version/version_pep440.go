@@ -0,0 +1,394 @@
+package checkers
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// pep440Pattern PEP 440附录中的版本号正则表达式，兼容各种非规范写法，如 1.0-alpha.1、1.0.RC2、1.0-1、v1.0
+var pep440Pattern = regexp.MustCompile(`^v?` +
+	`(?:(?P<epoch>[0-9]+)!)?` +
+	`(?P<release>[0-9]+(?:\.[0-9]+)*)` +
+	`(?:[-_.]?(?P<pre_l>alpha|beta|preview|pre|rc|a|b|c)[-_.]?(?P<pre_n>[0-9]+)?)?` +
+	`(?:-(?P<post_n1>[0-9]+)|[-_.]?(?P<post_l>post|rev|r)[-_.]?(?P<post_n2>[0-9]+)?)?` +
+	`(?:[-_.]?(?P<dev_l>dev)[-_.]?(?P<dev_n>[0-9]+)?)?` +
+	`(?:\+(?P<local>[a-z0-9]+(?:[-_.][a-z0-9]+)*))?$`)
+
+// pep440PreReleaseRank 预发布标记的顺序，只有开发版本标记时排在所有预发布版本之前，没有预发布标记时排在最后
+var pep440PreReleaseRank = map[string]int{"a": 0, "b": 1, "rc": 2}
+
+const (
+	pep440DevOnlyRank = -1
+	pep440FinalRank   = 3
+)
+
+// PEP440Version 按PEP 440解析的Python版本号
+type PEP440Version struct {
+	Epoch   int
+	Release []int
+	// PreLabel 规范化后的预发布标记（a、b、rc），为空表示不是预发布版本
+	PreLabel string
+	PreNum   int
+	// Post 后发布版本号，-1表示不是后发布版本
+	Post int
+	// Dev 开发版本号，-1表示不是开发版本
+	Dev   int
+	Local string
+}
+
+// ParsePEP440Version 解析PEP 440版本号，不区分大小写，非规范写法会被规范化
+func ParsePEP440Version(version string) (*PEP440Version, error) {
+	matches := pep440Pattern.FindStringSubmatch(strings.ToLower(strings.TrimSpace(version)))
+	if matches == nil {
+		return nil, fmt.Errorf("无效的PEP 440版本号: %s", version)
+	}
+	group := func(name string) string {
+		return matches[pep440Pattern.SubexpIndex(name)]
+	}
+
+	// 本地版本标签中的 - 和 _ 规范化为 .
+	local := strings.NewReplacer("-", ".", "_", ".").Replace(group("local"))
+	v := &PEP440Version{Post: -1, Dev: -1, Local: local}
+	if epoch := group("epoch"); epoch != "" {
+		v.Epoch, _ = strconv.Atoi(epoch)
+	}
+	for _, part := range strings.Split(group("release"), ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("无效的PEP 440版本号: %s", version)
+		}
+		v.Release = append(v.Release, n)
+	}
+
+	switch label := group("pre_l"); label {
+	case "":
+	case "alpha":
+		v.PreLabel = "a"
+	case "beta":
+		v.PreLabel = "b"
+	case "c", "pre", "preview":
+		v.PreLabel = "rc"
+	default:
+		v.PreLabel = label
+	}
+	if v.PreLabel != "" {
+		v.PreNum, _ = strconv.Atoi(group("pre_n"))
+	}
+
+	if post := group("post_n1"); post != "" {
+		v.Post, _ = strconv.Atoi(post)
+	} else if group("post_l") != "" {
+		v.Post, _ = strconv.Atoi(group("post_n2"))
+	}
+
+	if group("dev_l") != "" {
+		v.Dev, _ = strconv.Atoi(group("dev_n"))
+	}
+	return v, nil
+}
+
+// IsPrerelease 预发布版本和开发版本都不是稳定版本
+func (v *PEP440Version) IsPrerelease() bool {
+	return v.PreLabel != "" || v.Dev >= 0
+}
+
+// String 返回规范化的版本号
+func (v *PEP440Version) String() string {
+	var sb strings.Builder
+	if v.Epoch != 0 {
+		fmt.Fprintf(&sb, "%d!", v.Epoch)
+	}
+	for i, n := range v.Release {
+		if i > 0 {
+			sb.WriteByte('.')
+		}
+		sb.WriteString(strconv.Itoa(n))
+	}
+	if v.PreLabel != "" {
+		fmt.Fprintf(&sb, "%s%d", v.PreLabel, v.PreNum)
+	}
+	if v.Post >= 0 {
+		fmt.Fprintf(&sb, ".post%d", v.Post)
+	}
+	if v.Dev >= 0 {
+		fmt.Fprintf(&sb, ".dev%d", v.Dev)
+	}
+	if v.Local != "" {
+		sb.WriteString("+" + v.Local)
+	}
+	return sb.String()
+}
+
+// Compare 按PEP 440的顺序比较版本号，返回1表示v大于other，0表示相等，-1表示v小于other
+// 顺序为：1.0.dev0 < 1.0a1.dev0 < 1.0a1 < 1.0a1.post1 < 1.0rc1 < 1.0 < 1.0+local < 1.0.post1 < 1.1
+func (v *PEP440Version) Compare(other *PEP440Version) int {
+	if c := compareInt(v.Epoch, other.Epoch); c != 0 {
+		return c
+	}
+	if c := compareRelease(v.Release, other.Release); c != 0 {
+		return c
+	}
+
+	vRank, otherRank := v.preRank(), other.preRank()
+	if c := compareInt(vRank, otherRank); c != 0 {
+		return c
+	}
+	if vRank >= 0 && vRank < pep440FinalRank {
+		if c := compareInt(v.PreNum, other.PreNum); c != 0 {
+			return c
+		}
+	}
+
+	if c := compareInt(v.Post, other.Post); c != 0 {
+		return c
+	}
+	if c := compareInt(v.devKey(), other.devKey()); c != 0 {
+		return c
+	}
+	return compareLocal(v.Local, other.Local)
+}
+
+// preRank 预发布标记的排序值
+func (v *PEP440Version) preRank() int {
+	if v.PreLabel == "" {
+		if v.Post < 0 && v.Dev >= 0 {
+			return pep440DevOnlyRank
+		}
+		return pep440FinalRank
+	}
+	return pep440PreReleaseRank[v.PreLabel]
+}
+
+// devKey 开发版本号的排序值，不是开发版本时排在同一版本的所有开发版本之后
+func (v *PEP440Version) devKey() int {
+	if v.Dev < 0 {
+		return math.MaxInt
+	}
+	return v.Dev
+}
+
+// ComparePEP440Versions 按PEP 440比较两个版本号，无法解析的版本号小于可以解析的版本号
+func ComparePEP440Versions(v1, v2 string) int {
+	p1, err1 := ParsePEP440Version(v1)
+	p2, err2 := ParsePEP440Version(v2)
+	switch {
+	case err1 != nil && err2 != nil:
+		return strings.Compare(v1, v2)
+	case err1 != nil:
+		return -1
+	case err2 != nil:
+		return 1
+	}
+	return p1.Compare(p2)
+}
+
+// compareInt 比较两个整数
+func compareInt(a, b int) int {
+	switch {
+	case a > b:
+		return 1
+	case a < b:
+		return -1
+	}
+	return 0
+}
+
+// compareRelease 比较发布号，末尾的0不影响比较结果，如 1.0 == 1.0.0
+func compareRelease(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if c := compareInt(x, y); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// compareLocal 比较本地版本标签，没有标签的版本最小；数字段按数值比较并大于字母段
+func compareLocal(a, b string) int {
+	if a == "" || b == "" {
+		return compareInt(len(a), len(b))
+	}
+	splitter := func(r rune) bool { return r == '.' || r == '-' || r == '_' }
+	aParts, bParts := strings.FieldsFunc(a, splitter), strings.FieldsFunc(b, splitter)
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		aNum, aErr := strconv.Atoi(aParts[i])
+		bNum, bErr := strconv.Atoi(bParts[i])
+		var c int
+		switch {
+		case aErr == nil && bErr == nil:
+			c = compareInt(aNum, bNum)
+		case aErr == nil:
+			c = 1
+		case bErr == nil:
+			c = -1
+		default:
+			c = strings.Compare(aParts[i], bParts[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return compareInt(len(aParts), len(bParts))
+}
+
+// PEP440Specifier PEP 440版本约束中的一个条件，如 >=3.8、!=3.9.*、~=3.10
+type PEP440Specifier struct {
+	Operator string
+	Version  string
+}
+
+// PEP440SpecifierSet 以逗号分隔的PEP 440版本约束，所有条件都满足时才匹配，如PyPI的requires_python
+type PEP440SpecifierSet []PEP440Specifier
+
+// pep440SpecifierPattern 匹配单个版本约束条件
+var pep440SpecifierPattern = regexp.MustCompile(`^(===|~=|==|!=|<=|>=|<|>)\s*(\S+)$`)
+
+// ParsePEP440SpecifierSet 解析版本约束，空字符串表示不限制版本
+func ParsePEP440SpecifierSet(spec string) (PEP440SpecifierSet, error) {
+	var set PEP440SpecifierSet
+	for _, clause := range strings.Split(spec, ",") {
+		clause = strings.TrimSpace(clause)
+		if clause == "" {
+			continue
+		}
+		matches := pep440SpecifierPattern.FindStringSubmatch(clause)
+		if matches == nil {
+			return nil, fmt.Errorf("无效的版本约束: %s", clause)
+		}
+		if err := validatePEP440Specifier(matches[1], matches[2]); err != nil {
+			return nil, fmt.Errorf("无效的版本约束 %s: %v", clause, err)
+		}
+		set = append(set, PEP440Specifier{Operator: matches[1], Version: matches[2]})
+	}
+	return set, nil
+}
+
+// validatePEP440Specifier 检查约束中的版本号是否可以与运算符一起使用
+// .* 只能用于 == 和 !=，且前面只能是发布号；本地版本标签只能用于 == 和 !=；~= 的发布号至少有两段
+func validatePEP440Specifier(operator, version string) error {
+	if operator == "===" {
+		return nil
+	}
+
+	prefix := strings.TrimSuffix(version, ".*")
+	v, err := ParsePEP440Version(prefix)
+	if err != nil {
+		return err
+	}
+	equality := operator == "==" || operator == "!="
+	if prefix != version {
+		if !equality {
+			return fmt.Errorf("%s 不能与 .* 一起使用", operator)
+		}
+		if v.PreLabel != "" || v.Post >= 0 || v.Dev >= 0 || v.Local != "" {
+			return fmt.Errorf(".* 前面只能是发布号")
+		}
+	}
+	if v.Local != "" && !equality {
+		return fmt.Errorf("%s 不能与本地版本标签一起使用", operator)
+	}
+	if operator == "~=" && len(v.Release) < 2 {
+		return fmt.Errorf("~= 的发布号至少需要两段")
+	}
+	return nil
+}
+
+// Contains 检查版本是否满足所有约束条件，无法解析的版本号只能满足 === 约束
+func (s PEP440SpecifierSet) Contains(version string) bool {
+	v, _ := ParsePEP440Version(version)
+	for _, spec := range s {
+		if !spec.contains(version, v) {
+			return false
+		}
+	}
+	return true
+}
+
+// contains 检查版本是否满足单个约束条件，v为nil表示版本号无法解析
+func (s PEP440Specifier) contains(raw string, v *PEP440Version) bool {
+	if s.Operator == "===" {
+		return strings.EqualFold(strings.TrimSpace(raw), s.Version)
+	}
+	if v == nil {
+		return false
+	}
+
+	if prefix := strings.TrimSuffix(s.Version, ".*"); prefix != s.Version {
+		matched := v.hasPrefix(prefix)
+		if s.Operator == "!=" {
+			return !matched
+		}
+		return s.Operator == "==" && matched
+	}
+
+	target, err := ParsePEP440Version(s.Version)
+	if err != nil {
+		return false
+	}
+	// 约束中没有本地版本标签时，比较时忽略被检查版本的本地标签
+	candidate := v
+	if target.Local == "" && v.Local != "" {
+		withoutLocal := *v
+		withoutLocal.Local = ""
+		candidate = &withoutLocal
+	}
+
+	c := candidate.Compare(target)
+	switch s.Operator {
+	case "==":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<=":
+		return c <= 0
+	case ">=":
+		return c >= 0
+	case "<":
+		// <V 不包括V的预发布版本，除非V本身是预发布版本
+		return c < 0 && !(v.IsPrerelease() && !target.IsPrerelease() && v.sameBase(target))
+	case ">":
+		// >V 不包括V的后发布版本，除非V本身是后发布版本
+		return c > 0 && !(v.Post >= 0 && target.Post < 0 && v.sameBase(target))
+	case "~=":
+		// ~=X.Y.Z 等价于 >=X.Y.Z, ==X.Y.*
+		if c < 0 || len(target.Release) < 2 {
+			return false
+		}
+		prefix := target.Release[:len(target.Release)-1]
+		return target.Epoch == v.Epoch && compareRelease(v.Release[:min(len(v.Release), len(prefix))], prefix) == 0
+	}
+	return false
+}
+
+// sameBase 检查两个版本的epoch和发布号是否相同
+func (v *PEP440Version) sameBase(other *PEP440Version) bool {
+	return v.Epoch == other.Epoch && compareRelease(v.Release, other.Release) == 0
+}
+
+// hasPrefix 检查版本是否以指定的发布号开头，用于 ==X.Y.* 形式的约束
+func (v *PEP440Version) hasPrefix(prefix string) bool {
+	p, err := ParsePEP440Version(prefix)
+	if err != nil || p.Epoch != v.Epoch {
+		return false
+	}
+	for i, n := range p.Release {
+		current := 0
+		if i < len(v.Release) {
+			current = v.Release[i]
+		}
+		if current != n {
+			return false
+		}
+	}
+	return true
+}
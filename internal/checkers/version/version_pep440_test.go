@@ -0,0 +1,255 @@
+package checkers
+
+import "testing"
+
+// pep440OrderedVersions 按从小到大排列的版本号，来自packaging的测试用例，覆盖开发版本、预发布版本、后发布版本、本地版本标签和epoch
+var pep440OrderedVersions = []string{
+	// 隐式的epoch 0
+	"1.0.dev456",
+	"1.0a1",
+	"1.0a2.dev456",
+	"1.0a12.dev456",
+	"1.0a12",
+	"1.0b1.dev456",
+	"1.0b2",
+	"1.0b2.post345.dev456",
+	"1.0b2.post345",
+	"1.0b2-346",
+	"1.0c1.dev456",
+	"1.0c1",
+	"1.0rc2",
+	"1.0c3",
+	"1.0",
+	"1.0.post456.dev34",
+	"1.0.post456",
+	"1.1.dev1",
+	"1.2+123abc",
+	"1.2+123abc456",
+	"1.2+abc",
+	"1.2+abc123",
+	"1.2+abc123def",
+	"1.2+1234.abc",
+	"1.2+123456",
+	"1.2.r32+123456",
+	"1.2.rev33+123456",
+	// 显式的epoch 1
+	"1!1.0.dev456",
+	"1!1.0a1",
+	"1!1.0a2.dev456",
+	"1!1.0a12.dev456",
+	"1!1.0a12",
+	"1!1.0b1.dev456",
+	"1!1.0b2",
+	"1!1.0b2.post345.dev456",
+	"1!1.0b2.post345",
+	"1!1.0b2-346",
+	"1!1.0c1.dev456",
+	"1!1.0c1",
+	"1!1.0rc2",
+	"1!1.0c3",
+	"1!1.0",
+	"1!1.0.post456.dev34",
+	"1!1.0.post456",
+	"1!1.1.dev1",
+	"1!1.2+123abc",
+	"1!1.2+123abc456",
+	"1!1.2+abc",
+	"1!1.2+abc123",
+	"1!1.2+abc123def",
+	"1!1.2+1234.abc",
+	"1!1.2+123456",
+	"1!1.2.r32+123456",
+	"1!1.2.rev33+123456",
+}
+
+func TestComparePEP440VersionsOrdering(t *testing.T) {
+	for i, smaller := range pep440OrderedVersions {
+		for j, larger := range pep440OrderedVersions {
+			want := compareInt(i, j)
+			if got := ComparePEP440Versions(smaller, larger); got != want {
+				t.Errorf("ComparePEP440Versions(%q, %q) = %d，期望 %d", smaller, larger, got, want)
+			}
+		}
+	}
+}
+
+func TestComparePEP440Versions(t *testing.T) {
+	tests := []struct {
+		v1   string
+		v2   string
+		want int
+	}{
+		// PEP 440 中的顺序说明
+		{"1.0.dev0", "1.0a1.dev0", -1},
+		{"1.0a1.dev0", "1.0a1", -1},
+		{"1.0a1", "1.0a1.post1", -1},
+		{"1.0a1.post1", "1.0rc1", -1},
+		{"1.0rc1", "1.0", -1},
+		{"1.0", "1.0+local", -1},
+		{"1.0+local", "1.0.post1", -1},
+		{"1.0.post1", "1.1", -1},
+		{"1.0.post1.dev1", "1.0.post1", -1},
+		{"1.0", "1.0.post1.dev1", -1},
+		{"1.1.dev1", "1.0.post1", 1},
+		// 末尾的0和前导0不影响比较
+		{"1.0", "1.0.0", 0},
+		{"1.0.0.0", "1", 0},
+		{"01.02", "1.2", 0},
+		{"1.10", "1.9", 1},
+		// 非规范写法
+		{"1.0-alpha.1", "1.0a1", 0},
+		{"1.0.RC2", "1.0rc2", 0},
+		{"1.0preview1", "1.0rc1", 0},
+		{"1.0-1", "1.0.post1", 0},
+		{"1.0.rev1", "1.0.post1", 0},
+		{"v1.0", "1.0", 0},
+		{"1.0.DEV", "1.0.dev0", 0},
+		{"1.0+Ubuntu-1", "1.0+ubuntu.1", 0},
+		// epoch优先于发布号
+		{"1!0.1", "2024.1", 1},
+		{"0!1.0", "1.0", 0},
+		// 无法解析的版本号小于可以解析的版本号
+		{"not-a-version", "0.1", -1},
+		{"0.1", "not-a-version", 1},
+	}
+	for _, tt := range tests {
+		if got := ComparePEP440Versions(tt.v1, tt.v2); got != tt.want {
+			t.Errorf("ComparePEP440Versions(%q, %q) = %d，期望 %d", tt.v1, tt.v2, got, tt.want)
+		}
+		if got := ComparePEP440Versions(tt.v2, tt.v1); got != -tt.want {
+			t.Errorf("ComparePEP440Versions(%q, %q) = %d，期望 %d", tt.v2, tt.v1, got, -tt.want)
+		}
+	}
+}
+
+func TestParsePEP440Version(t *testing.T) {
+	tests := []struct {
+		version      string
+		want         string
+		isPrerelease bool
+	}{
+		{"1.0", "1.0", false},
+		{"1.0a", "1.0a0", true},
+		{"1.0-beta.2", "1.0b2", true},
+		{"1.0c1", "1.0rc1", true},
+		{"1.0-pre3", "1.0rc3", true},
+		{"1.0.post", "1.0.post0", false},
+		{"1.0_r2", "1.0.post2", false},
+		{"1.0-5", "1.0.post5", false},
+		{"1.0dev", "1.0.dev0", true},
+		{"1.0.post1.dev2", "1.0.post1.dev2", true},
+		{"2!1.0RC1.post2.dev3+Local_Tag-7", "2!1.0rc1.post2.dev3+local.tag.7", true},
+		{"  V3.2.1  ", "3.2.1", false},
+	}
+	for _, tt := range tests {
+		v, err := ParsePEP440Version(tt.version)
+		if err != nil {
+			t.Errorf("ParsePEP440Version(%q) 返回错误: %v", tt.version, err)
+			continue
+		}
+		if got := v.String(); got != tt.want {
+			t.Errorf("ParsePEP440Version(%q).String() = %q，期望 %q", tt.version, got, tt.want)
+		}
+		if got := v.IsPrerelease(); got != tt.isPrerelease {
+			t.Errorf("ParsePEP440Version(%q).IsPrerelease() = %v，期望 %v", tt.version, got, tt.isPrerelease)
+		}
+	}
+
+	for _, version := range []string{"", "1.0-", "1.0.", "1.0+", "1.0+local+tag", "a1.0", "1.0.gamma1", "1..0"} {
+		if _, err := ParsePEP440Version(version); err == nil {
+			t.Errorf("ParsePEP440Version(%q) 期望返回错误", version)
+		}
+	}
+}
+
+func TestPEP440SpecifierSetContains(t *testing.T) {
+	tests := []struct {
+		spec    string
+		version string
+		want    bool
+	}{
+		// 兼容版本 ~=
+		{"~=2.0", "2.0", true},
+		{"~=2.0", "2.9", true},
+		{"~=2.0", "2.0.post1", true},
+		{"~=2.0", "3.0", false},
+		{"~=2.0", "1.9", false},
+		{"~=2.2.0", "2.2.1", true},
+		{"~=2.2.0", "2.3", false},
+		{"~=2.2.post3", "2.2", false},
+		{"~=2.2.post3", "2.3", true},
+		{"~=1.4.5a4", "1.4.5", true},
+		{"~=1.4.5a4", "1.5", false},
+		{"~=1!2.0", "2.1", false},
+		// 前缀匹配 ==X.* 和 !=X.*
+		{"==3.1.*", "3.1", true},
+		{"==3.1.*", "3.1.5", true},
+		{"==3.1.*", "3.10", false},
+		{"==3.*", "3.12.1", true},
+		{"==1.0.*", "1.0.post1", true},
+		{"!=3.0.*", "3.0.5", false},
+		{"!=3.0.*", "3.1", true},
+		// 相等和本地版本标签
+		{"==1.0", "1.0.0", true},
+		{"==1.0", "1.0+local", true},
+		{"==1.0+local", "1.0", false},
+		{"==1.0+local", "1.0+local", true},
+		{"!=1.0", "1.0+local", false},
+		{"===foobar", "foobar", true},
+		{"===1.0", "1.0.0", false},
+		// 有序比较
+		{">=1.7", "1.7", true},
+		{"<=1.7", "1.7.post1", false},
+		{"<=1.7", "1.7+local", true},
+		{">1.7", "1.7.1", true},
+		{">1.7", "1.7.post1", false},
+		{">1.7", "1.7+local", false},
+		{">1.7.post2", "1.7.post3", true},
+		{">1.7.post2", "1.7.1", true},
+		{"<2.0", "1.9", true},
+		{"<2.0", "2.0rc1", false},
+		{"<2.0", "2.0.dev1", false},
+		{"<2.0rc1", "2.0b1", true},
+		{"<2.0", "2.0.post1", false},
+		// requires_python
+		{">=3.8", "3.12", true},
+		{">= 3.8", "3.7", false},
+		{">=3.6, !=3.0.*, !=3.1.*, !=3.2.*, <4", "3.12", true},
+		{">=2.7, !=3.0.*, !=3.1.*, !=3.2.*, <4", "3.1", false},
+		{">=3.7,<3.11", "3.11", false},
+		{">=3.7,<3.11", "3.10", true},
+		{"!=3.9.*", "3.9", false},
+		{"", "3.12", true},
+		// 无法解析的被检查版本
+		{">=3.8", "latest", false},
+	}
+	for _, tt := range tests {
+		set, err := ParsePEP440SpecifierSet(tt.spec)
+		if err != nil {
+			t.Errorf("ParsePEP440SpecifierSet(%q) 返回错误: %v", tt.spec, err)
+			continue
+		}
+		if got := set.Contains(tt.version); got != tt.want {
+			t.Errorf("ParsePEP440SpecifierSet(%q).Contains(%q) = %v，期望 %v", tt.spec, tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestParsePEP440SpecifierSetErrors(t *testing.T) {
+	for _, spec := range []string{
+		"3.8",
+		"=>3.8",
+		">=",
+		"~=2",
+		"~=2.0.*",
+		">=3.6.*",
+		"<3.*",
+		"==3.*.1",
+		"==1.0+local.*",
+		">=3.8,foo",
+	} {
+		if _, err := ParsePEP440SpecifierSet(spec); err == nil {
+			t.Errorf("ParsePEP440SpecifierSet(%q) 期望返回错误", spec)
+		}
+	}
+}
@@ -112,6 +112,9 @@ type GlobalConfig struct {
 
 	// 缓存TTL（分钟）
 	CacheTTL int `json:"cacheTTL"`
+
+	// 是否允许命令检查器运行软件包中配置的命令，默认关闭
+	EnableCmdChecker bool `json:"enableCmdChecker"`
 }
 
 var (
@@ -216,7 +219,8 @@ func GetDefaultConfig() *Config {
 					Timeout:     30,
 					RetryCount:  3,
 					CustomParams: map[string]interface{}{
-						"api_token": "",
+						"api_token":           "",
+						"rate_limit_max_wait": 30,
 					},
 				},
 				"gitlab": {
@@ -227,6 +231,49 @@ func GetDefaultConfig() *Config {
 						"api_token": "",
 					},
 				},
+				"gitea": {
+					Priority:    80,
+					Timeout:     30,
+					RetryCount:  3,
+					CustomParams: map[string]interface{}{
+						"hosts":     []string{"codeberg.org", "gitea.com"},
+						"api_token": "",
+					},
+				},
+				"gomod": {
+					Priority:    80,
+					Timeout:     20,
+					RetryCount:  2,
+					CustomParams: map[string]interface{}{
+						"proxy": "",
+					},
+				},
+				"pypi": {
+					Priority:    80,
+					Timeout:     20,
+					RetryCount:  2,
+					CustomParams: map[string]interface{}{
+						"python_version": "",
+					},
+				},
+				"npm": {
+					Priority:    75,
+					Timeout:     20,
+					RetryCount:  2,
+					CustomParams: map[string]interface{}{
+						"registries": []string{"https://registry.npmjs.org", "https://registry.npmmirror.com"},
+						"scopes":     map[string]string{},
+						"tokens":     map[string]string{},
+					},
+				},
+				"vsx": {
+					Priority:    80,
+					Timeout:     20,
+					RetryCount:  2,
+					CustomParams: map[string]interface{}{
+						"base_url": "https://open-vsx.org",
+					},
+				},
 				"http": {
 					Priority:    50,
 					Timeout:     20,
@@ -237,6 +284,17 @@ func GetDefaultConfig() *Config {
 					Timeout:     15,
 					RetryCount:  2,
 				},
+				"cmd": {
+					Priority:    0,
+					Timeout:     30,
+					RetryCount:  0,
+					CustomParams: map[string]interface{}{
+						"shell":    "",
+						"work_dir": "",
+						"pass_env": []string{},
+						"env":      map[string]string{},
+					},
+				},
 			},
 			URLRules: []URLRule{
 				{
@@ -251,18 +309,90 @@ func GetDefaultConfig() *Config {
 					Checker:          "gitlab",
 					Priority:         85,
 				},
+				{
+					Name:             "Codeberg",
+					Pattern:          `^https://codeberg\.org/.+`,
+					Checker:          "gitea",
+					Priority:         85,
+				},
+				{
+					Name:             "SourceHut",
+					Pattern:          `^https://git\.sr\.ht/.+`,
+					Checker:          "gitrefs",
+					Priority:         85,
+				},
 				{
 					Name:             "PyPI",
 					Pattern:          `^https://pypi\.org/.+`,
 					Checker:          "pypi",
 					Priority:         80,
 				},
+				{
+					Name:             "crates.io",
+					Pattern:          `^https://crates\.io/crates/.+`,
+					Checker:          "crates",
+					Priority:         80,
+				},
+				{
+					Name:             "Go模块",
+					Pattern:          `^https://pkg\.go\.dev/.+`,
+					Checker:          "gomod",
+					Priority:         80,
+				},
+				{
+					Name:             "Maven",
+					Pattern:          `^https://(?:(?:central\.sonatype\.com|mvnrepository\.com)/artifact/[^/]+/[^/]+|repo1\.maven\.org/maven2/[^/]+/[^/]+)`,
+					Checker:          "maven",
+					Priority:         80,
+				},
+				{
+					Name:             "RubyGems",
+					Pattern:          `^https://rubygems\.org/gems/.+`,
+					Checker:          "rubygems",
+					Priority:         80,
+				},
+				{
+					Name:             "Packagist",
+					Pattern:          `^https://packagist\.org/packages/.+`,
+					Checker:          "packagist",
+					Priority:         80,
+				},
+				{
+					Name:             "Hackage",
+					Pattern:          `^https://hackage\.haskell\.org/package/.+`,
+					Checker:          "hackage",
+					Priority:         80,
+				},
+				{
+					Name:             "MetaCPAN",
+					Pattern:          `^https://metacpan\.org/(?:dist|release|pod)/.+`,
+					Checker:          "metacpan",
+					Priority:         80,
+				},
+				{
+					Name:             "Open VSX",
+					Pattern:          `^https://(?:open-vsx\.org/extension|marketplace\.visualstudio\.com/items\?).+`,
+					Checker:          "vsx",
+					Priority:         80,
+				},
+				{
+					Name:             "Docker Hub",
+					Pattern:          `^https://hub\.docker\.com/.+`,
+					Checker:          "oci",
+					Priority:         75,
+				},
 				{
 					Name:             "NPM",
 					Pattern:          `^https://www\.npmjs\.com/.+`,
 					Checker:          "npm",
 					Priority:         75,
 				},
+				{
+					Name:             "SourceForge",
+					Pattern:          `^https://(?:[^/]+\.)?sourceforge\.(?:net|io)/.+`,
+					Checker:          "sourceforge",
+					Priority:         70,
+				},
 			},
 		},
 		Plugins: PluginConfig{
@@ -277,6 +407,7 @@ func GetDefaultConfig() *Config {
 			MaxConcurrentChecks: 10,
 			AsyncWorkerCount:    5,
 			CacheTTL:            5,
+			EnableCmdChecker:    false,
 		},
 	}
 }
@@ -173,6 +173,10 @@ func (a *UpstreamCheckerAdapter) CheckWithVersionRef(ctx context.Context, url, v
 	return a.checker.CheckWithOption(ctx, url, versionExtractKey, checkTestVersion)
 }
 
+func (a *UpstreamCheckerAdapter) CheckWithResult(ctx context.Context, url, versionExtractKey, versionRef string, checkTestVersion int) (*common.UpstreamCheckResult, error) {
+	return a.checker.CheckWithResult(ctx, url, versionExtractKey, versionRef, checkTestVersion)
+}
+
 func (a *UpstreamCheckerAdapter) Name() string {
 	return a.checker.Name()
 }
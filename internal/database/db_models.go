@@ -12,6 +12,7 @@ type PackageInfo struct {
 	UpstreamChecker  string `gorm:"type:text;not null;index" json:"upstreamChecker"`
 	VersionExtractKey string `gorm:"type:text;not null" json:"versionExtractKey"`
 	CheckTestVersion int    `gorm:"default:0;index" json:"checkTestVersion"` // 0:不检查测试版本,1:检查测试版本
+	CheckerOptions   string `gorm:"type:text;not null;default:''" json:"checkerOptions"` // 检查器选项，查询字符串格式
 
 	AurInfo          *AurInfo      `gorm:"foreignKey:PackageID" json:"aurInfo"`
 	UpstreamInfo     *UpstreamInfo `gorm:"foreignKey:PackageID" json:"upstreamInfo"`
@@ -43,6 +44,13 @@ type UpstreamInfo struct {
 	UpstreamVersion     string     `gorm:"type:text;not null" json:"upstreamVersion"`
 	UpstreamUpdateDate  time.Time  `json:"upstreamUpdateDate"`
 	UpstreamUpdateState int        `gorm:"default:0;index" json:"upstreamUpdateState"` // 0:未检查,1:成功,2:失败
+	UpstreamAssetName   string     `gorm:"type:text" json:"upstreamAssetName"`   // 匹配到的发布附件名称
+	UpstreamAssetUrl    string     `gorm:"type:text" json:"upstreamAssetUrl"`    // 发布附件的下载地址
+	UpstreamAssetSize   int64      `gorm:"default:0" json:"upstreamAssetSize"`   // 发布附件大小(字节)，未知时为0
+	UpstreamAssetSha256 string     `gorm:"type:text" json:"upstreamAssetSha256"` // 上游提供的发布附件sha256校验和，十六进制
+	UpstreamAssetSha512 string     `gorm:"type:text" json:"upstreamAssetSha512"` // 上游提供的发布附件sha512校验和，base64编码
+	UpstreamPublishedAt time.Time  `json:"upstreamPublishedAt"`                  // 上游发布时间，未知时为零值
+	UpstreamSourceUrl   string     `gorm:"type:text" json:"upstreamSourceUrl"`   // 上游版本信息的来源页面，如发布页面
 
 	PackageInfo         *PackageInfo `gorm:"foreignKey:PackageID" json:"-"`
 
@@ -58,6 +66,7 @@ type PackageDetail struct {
 	UpstreamChecker    string `json:"upstreamChecker"`
 	VersionExtractKey  string `json:"versionExtractKey"`
 	CheckTestVersion   int    `json:"checkTestVersion"` // 0:不检查测试版本,1:检查测试版本
+	CheckerOptions     string `json:"checkerOptions"`
 
 	// AUR信息
 	AurVersion         string    `json:"aurVersion"`
@@ -70,6 +79,16 @@ type PackageDetail struct {
 	UpstreamVersion    string    `json:"upstreamVersion"`
 	UpstreamUpdateDate time.Time `json:"upstreamUpdateDate"`
 	UpstreamUpdateState int      `json:"upstreamUpdateState"`
+	UpstreamAssetName   string    `json:"upstreamAssetName"`
+	UpstreamAssetUrl    string    `json:"upstreamAssetUrl"`
+	UpstreamAssetSize   int64     `json:"upstreamAssetSize"`
+	UpstreamAssetSha256 string    `json:"upstreamAssetSha256"`
+	UpstreamAssetSha512 string    `json:"upstreamAssetSha512"`
+	UpstreamPublishedAt time.Time `json:"upstreamPublishedAt"`
+	UpstreamSourceUrl   string    `json:"upstreamSourceUrl"`
+
+	// NeedUpdate AUR版本是否落后于上游版本，由服务层根据版本比较结果设置
+	NeedUpdate          bool      `json:"needUpdate"`
 
 	CreatedAt          time.Time `json:"createdAt"`
 	UpdatedAt          time.Time `json:"updatedAt"`
@@ -84,6 +103,7 @@ func (p *PackageInfo) ToPackageDetail() PackageDetail {
 		UpstreamChecker:   p.UpstreamChecker,
 		VersionExtractKey: p.VersionExtractKey,
 		CheckTestVersion:  p.CheckTestVersion,
+		CheckerOptions:    p.CheckerOptions,
 		CreatedAt:         p.CreatedAt,
 		UpdatedAt:         p.UpdatedAt,
 	}
@@ -100,6 +120,13 @@ func (p *PackageInfo) ToPackageDetail() PackageDetail {
 		detail.UpstreamVersion = p.UpstreamInfo.UpstreamVersion
 		detail.UpstreamUpdateDate = p.UpstreamInfo.UpstreamUpdateDate
 		detail.UpstreamUpdateState = p.UpstreamInfo.UpstreamUpdateState
+		detail.UpstreamAssetName = p.UpstreamInfo.UpstreamAssetName
+		detail.UpstreamAssetUrl = p.UpstreamInfo.UpstreamAssetUrl
+		detail.UpstreamAssetSize = p.UpstreamInfo.UpstreamAssetSize
+		detail.UpstreamAssetSha256 = p.UpstreamInfo.UpstreamAssetSha256
+		detail.UpstreamAssetSha512 = p.UpstreamInfo.UpstreamAssetSha512
+		detail.UpstreamPublishedAt = p.UpstreamInfo.UpstreamPublishedAt
+		detail.UpstreamSourceUrl = p.UpstreamInfo.UpstreamSourceUrl
 	}
 
 	return detail
@@ -54,10 +54,10 @@ func (h *PackageHandler) GetPackageByID(id int) (map[string]interface{}, error)
 }
 
 // AddPackage 添加软件包
-func (h *PackageHandler) AddPackage(name, upstreamUrl, versionExtractKey, upstreamChecker string, checkTestVersion int) (map[string]interface{}, error) {
+func (h *PackageHandler) AddPackage(name, upstreamUrl, versionExtractKey, upstreamChecker string, checkTestVersion int, checkerOptions string) (map[string]interface{}, error) {
 	h.log.Infof("处理器接收到添加软件包请求: 名称=%s, 上游URL=%s, 版本提取键=%s, 上游检查器=%s", name, upstreamUrl, versionExtractKey, upstreamChecker)
 	
-	pkg, err := h.packageService.AddPackage(name, upstreamUrl, versionExtractKey, upstreamChecker, checkTestVersion)
+	pkg, err := h.packageService.AddPackage(name, upstreamUrl, versionExtractKey, upstreamChecker, checkTestVersion, checkerOptions)
 	if err != nil {
 		h.log.Errorf("处理器添加软件包失败: %v", err)
 		h.log.Errorf("错误类型: %T", err)
@@ -75,8 +75,8 @@ func (h *PackageHandler) AddPackage(name, upstreamUrl, versionExtractKey, upstre
 }
 
 // UpdatePackage 更新软件包
-func (h *PackageHandler) UpdatePackage(id int, name, upstreamUrl, versionExtractKey, upstreamChecker string, checkTestVersion int) (map[string]interface{}, error) {
-	pkg, err := h.packageService.UpdatePackage(id, name, upstreamUrl, versionExtractKey, upstreamChecker, checkTestVersion)
+func (h *PackageHandler) UpdatePackage(id int, name, upstreamUrl, versionExtractKey, upstreamChecker string, checkTestVersion int, checkerOptions string) (map[string]interface{}, error) {
+	pkg, err := h.packageService.UpdatePackage(id, name, upstreamUrl, versionExtractKey, upstreamChecker, checkTestVersion, checkerOptions)
 	if err != nil {
 		h.log.Errorf("更新软件包失败(ID: %d): %v", id, err)
 		return nil, err
@@ -207,6 +207,10 @@ func NewCheckerFactoryWithLogger(logProvider LoggerProvider) *CheckerFactory {
 			logger.GlobalLogger.Errorf("创建检查器 '%s' 失败: %v", name, err)
 			continue
 		}
+		// 将配置文件中的检查器设置应用到实例上
+		if settings, ok := cfg.Checkers.Settings[name]; ok {
+			ApplyConfigToChecker(checker, settings)
+		}
 		factory.RegisterChecker(name, checker)
 		logger.GlobalLogger.Infof("已实例化检查器: %s", name)
 	}
@@ -343,6 +347,24 @@ func (f *CheckerFactory) CheckWithVersionRef(ctx context.Context, checkerName, u
 	}
 }
 
+// CheckWithResult 使用指定检查器检查上游版本，返回包含发布时间、来源地址和附件的完整结果
+func (f *CheckerFactory) CheckWithResult(ctx context.Context, checkerName, url, versionExtractKey, versionRef string, checkTestVersion int) (*common.UpstreamCheckResult, error) {
+	checker, err := f.GetChecker(checkerName)
+	if err != nil {
+		logger.GlobalLogger.Errorf("获取检查器 '%s' 失败: %v", checkerName, err)
+		return nil, fmt.Errorf("获取检查器失败: %v", err)
+	}
+
+	logger.GlobalLogger.Infof("使用检查器 '%s' 检查上游版本 - URL: %s, 版本引用: %s, 检查测试版本: %d", checkerName, url, versionRef, checkTestVersion)
+	result, err := checker.CheckWithResult(ctx, url, versionExtractKey, versionRef, checkTestVersion)
+	if err != nil {
+		logger.GlobalLogger.Errorf("使用检查器 '%s' 检查版本失败: %v", checkerName, err)
+		return nil, err
+	}
+	logger.GlobalLogger.Infof("检查器 '%s' 成功获取版本: %s，发布时间: %v，附件数量: %d", checkerName, result.Version, result.ReleaseDate, len(result.Assets))
+	return result, nil
+}
+
 // createConcurrentChecker 创建并发检查器
 func (f *CheckerFactory) createConcurrentChecker(cacheTTL time.Duration) common.ConcurrentCheckerInterface {
 	// 这是一个空实现，具体实现会在 types 包中提供
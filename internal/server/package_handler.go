@@ -39,6 +39,7 @@ func (s *APIServer) addPackage(w http.ResponseWriter, r *http.Request) {
 		VersionExtractKey string `json:"versionExtractKey"`
 		UpstreamChecker   string `json:"upstreamChecker"`
 		CheckTestVersion  int    `json:"checkTestVersion"`
+		CheckerOptions    string `json:"checkerOptions"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
@@ -46,7 +47,7 @@ func (s *APIServer) addPackage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, err := s.packageService.AddPackage(data.Name, data.UpstreamUrl, data.VersionExtractKey, data.UpstreamChecker, data.CheckTestVersion)
+	result, err := s.packageService.AddPackage(data.Name, data.UpstreamUrl, data.VersionExtractKey, data.UpstreamChecker, data.CheckTestVersion, data.CheckerOptions)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -67,6 +68,7 @@ func (s *APIServer) updatePackage(w http.ResponseWriter, r *http.Request) {
 		VersionExtractKey string `json:"versionExtractKey"`
 		UpstreamChecker   string `json:"upstreamChecker"`
 		CheckTestVersion  int    `json:"checkTestVersion"`
+		CheckerOptions    string `json:"checkerOptions"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
@@ -74,7 +76,7 @@ func (s *APIServer) updatePackage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, err := s.packageService.UpdatePackage(id, data.Name, data.UpstreamUrl, data.VersionExtractKey, data.UpstreamChecker, data.CheckTestVersion)
+	result, err := s.packageService.UpdatePackage(id, data.Name, data.UpstreamUrl, data.VersionExtractKey, data.UpstreamChecker, data.CheckTestVersion, data.CheckerOptions)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -25,7 +25,8 @@ func (s *APIServer) checkUpstreamVersion(w http.ResponseWriter, r *http.Request)
 
 // checkAllUpstreamVersions 检查所有上游版本
 func (s *APIServer) checkAllUpstreamVersions(w http.ResponseWriter, r *http.Request) {
-	results, err := s.upstreamService.CheckAllUpstreamVersions()
+	// 客户端断开连接时不再等待频率限制重置
+	results, err := s.upstreamService.CheckAllUpstreamVersionsWithContext(r.Context())
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -52,7 +52,7 @@ func (s *AurService) getPackageDetailWithAur(packageID int) (database.PackageDet
 		return database.PackageDetail{}, err
 	}
 
-	return pkg.ToPackageDetail(), nil
+	return newPackageDetail(&pkg), nil
 }
 
 // batchGetPackageDetails 批量获取软件包详情
@@ -67,7 +67,7 @@ func (s *AurService) batchGetPackageDetails(packageIDs []int) ([]database.Packag
 
 	// 转换为PackageDetail
 	for _, pkg := range packages {
-		results = append(results, pkg.ToPackageDetail())
+		results = append(results, newPackageDetail(&pkg))
 	}
 
 	return results, nil
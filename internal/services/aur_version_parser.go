@@ -1,9 +1,25 @@
 package services
 
 import (
+	"regexp"
+	"strconv"
 	"strings"
+
+	"aur-update-checker/internal/database"
+	"aur-update-checker/internal/utils"
 )
 
+// vcsVersionPattern 匹配makepkg风格的VCS版本号，如 r1234.abcdef0 或 1.2.3.r45.gabcdef0
+// 短哈希至少7位（git rev-parse --short 的默认长度），避免把 r2.beta 之类的版本号当作VCS版本号
+var vcsVersionPattern = regexp.MustCompile(`^(?:(.+)[.+-])?r(\d+)\.g?([0-9a-f]{7,40})$`)
+
+// vcsVersion VCS版本号的组成部分
+type vcsVersion struct {
+	prefix string // 最近标签的版本号，没有时为空
+	count  int    // 提交数
+	hash   string // 提交短哈希
+}
+
 // AurVersionParser AUR版本解析器
 type AurVersionParser struct{}
 
@@ -38,3 +54,69 @@ func (p *AurVersionParser) ExtractPkgver(fullVersion string) string {
 func (p *AurVersionParser) ParseAndSaveVersion(fullVersion string) string {
 	return p.ExtractPkgver(fullVersion)
 }
+
+// CompareVersions 比较AUR版本和上游版本
+// 返回值: -1表示version1小于version2, 0表示相等, 1表示version1大于version2
+// 两个版本都是VCS版本号（r<提交数>.<短哈希>）时按提交数比较，提交数和提交哈希都相同时视为相等
+func (p *AurVersionParser) CompareVersions(version1, version2 string) int {
+	vcs1, ok1 := parseVCSVersion(version1)
+	vcs2, ok2 := parseVCSVersion(version2)
+	if !ok1 || !ok2 {
+		return utils.CompareVersionStrings(version1, version2)
+	}
+
+	// 短哈希长度可能不同，提交数相同且一个是另一个的前缀时是同一个提交，不再比较标签版本号
+	if vcs1.count == vcs2.count && (strings.HasPrefix(vcs1.hash, vcs2.hash) || strings.HasPrefix(vcs2.hash, vcs1.hash)) {
+		return 0
+	}
+
+	// 都带有标签版本号时先比较标签版本号，提交数只在同一个标签之后有意义
+	if vcs1.prefix != "" && vcs2.prefix != "" && vcs1.prefix != vcs2.prefix {
+		return utils.CompareVersionStrings(vcs1.prefix, vcs2.prefix)
+	}
+
+	switch {
+	case vcs1.count < vcs2.count:
+		return -1
+	case vcs1.count > vcs2.count:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// NeedsUpdate 判断AUR版本是否落后于上游版本，任一版本为空时返回false
+// VCS版本号按提交数判断，其他版本号只要不同就需要更新
+func (p *AurVersionParser) NeedsUpdate(aurVersion, upstreamVersion string) bool {
+	if aurVersion == "" || upstreamVersion == "" {
+		return false
+	}
+
+	_, aurIsVCS := parseVCSVersion(aurVersion)
+	_, upstreamIsVCS := parseVCSVersion(upstreamVersion)
+	if aurIsVCS && upstreamIsVCS {
+		return p.CompareVersions(aurVersion, upstreamVersion) < 0
+	}
+	return aurVersion != upstreamVersion
+}
+
+// parseVCSVersion 解析makepkg风格的VCS版本号
+func parseVCSVersion(version string) (vcsVersion, bool) {
+	matches := vcsVersionPattern.FindStringSubmatch(strings.ToLower(version))
+	if matches == nil {
+		return vcsVersion{}, false
+	}
+
+	count, err := strconv.Atoi(matches[2])
+	if err != nil {
+		return vcsVersion{}, false
+	}
+	return vcsVersion{prefix: matches[1], count: count, hash: matches[3]}, true
+}
+
+// newPackageDetail 将软件包转换为详情格式，并根据AUR版本和上游版本判断是否需要更新
+func newPackageDetail(pkg *database.PackageInfo) database.PackageDetail {
+	detail := pkg.ToPackageDetail()
+	detail.NeedUpdate = NewAurVersionParser().NeedsUpdate(detail.AurVersion, detail.UpstreamVersion)
+	return detail
+}
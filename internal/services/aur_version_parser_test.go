@@ -0,0 +1,52 @@
+package services
+
+import "testing"
+
+func TestAurVersionParserCompareVCSVersions(t *testing.T) {
+	tests := []struct {
+		name     string
+		version1 string
+		version2 string
+		want     int
+	}{
+		{"相同的提交", "r1234.abcdef0", "r1234.abcdef0", 0},
+		{"短哈希长度不同的同一个提交", "r1234.abcdef0", "r1234.abcdef0123", 0},
+		{"提交数较少", "r1233.1234567", "r1234.abcdef0", -1},
+		{"提交数较多", "r1235.1234567", "r1234.abcdef0", 1},
+		{"短哈希是前缀但提交数不同", "r100.abcdef0", "r120.abcdef0123", -1},
+		{"标签版本号写法不同的同一个提交", "1.2.3.r45.gabcdef0", "v1.2.3.r45.gabcdef0", 0},
+		{"先比较标签版本号", "1.2.4.r1.g1234567", "1.2.3.r45.gabcdef0", 1},
+		{"同一个标签之后按提交数比较", "1.2.3.r44.g1234567", "1.2.3.r45.gabcdef0", -1},
+		{"4位哈希不是VCS版本号", "r2.beef", "r10.abcdef0", -1},
+	}
+
+	parser := NewAurVersionParser()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parser.CompareVersions(tt.version1, tt.version2); got != tt.want {
+				t.Errorf("CompareVersions(%q, %q) = %d，期望 %d", tt.version1, tt.version2, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseVCSVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    vcsVersion
+		ok      bool
+	}{
+		{"r1234.abcdef0", vcsVersion{count: 1234, hash: "abcdef0"}, true},
+		{"1.2.3.r45.gABCDEF0", vcsVersion{prefix: "1.2.3", count: 45, hash: "abcdef0"}, true},
+		{"2.0+r7.g0123456789abcdef0123456789abcdef01234567", vcsVersion{prefix: "2.0", count: 7, hash: "0123456789abcdef0123456789abcdef01234567"}, true},
+		{"r12.abcdef", vcsVersion{}, false},
+		{"r2.beef", vcsVersion{}, false},
+		{"1.2.3", vcsVersion{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseVCSVersion(tt.version)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("parseVCSVersion(%q) = %+v, %v，期望 %+v, %v", tt.version, got, ok, tt.want, tt.ok)
+		}
+	}
+}
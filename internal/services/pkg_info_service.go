@@ -38,7 +38,7 @@ func (s *PackageService) GetAllPackages() ([]database.PackageDetail, error) {
 
 	// 转换为PackageDetail
 	for _, pkg := range packages {
-		result = append(result, pkg.ToPackageDetail())
+		result = append(result, newPackageDetail(&pkg))
 	}
 
 	return result, nil
@@ -54,12 +54,12 @@ func (s *PackageService) GetPackageByID(id int) (database.PackageDetail, error)
 		return database.PackageDetail{}, err
 	}
 
-	return pkg.ToPackageDetail(), nil
+	return newPackageDetail(&pkg), nil
 }
 
 // AddPackage 添加软件包
-func (s *PackageService) AddPackage(name, upstreamUrl, versionExtractKey, upstreamChecker string, checkTestVersion int) (database.PackageDetail, error) {
-	s.log.Infof("尝试添加软件包: 名称=%s, 上游URL=%s, 版本提取键=%s, 上游检查器=%s, 检查测试版本=%d", name, upstreamUrl, versionExtractKey, upstreamChecker, checkTestVersion)
+func (s *PackageService) AddPackage(name, upstreamUrl, versionExtractKey, upstreamChecker string, checkTestVersion int, checkerOptions string) (database.PackageDetail, error) {
+	s.log.Infof("尝试添加软件包: 名称=%s, 上游URL=%s, 版本提取键=%s, 上游检查器=%s, 检查测试版本=%d, 检查器选项=%s", name, upstreamUrl, versionExtractKey, upstreamChecker, checkTestVersion, checkerOptions)
 	
 	// 验证输入参数
 	if name == "" {
@@ -87,6 +87,7 @@ func (s *PackageService) AddPackage(name, upstreamUrl, versionExtractKey, upstre
 		VersionExtractKey: versionExtractKey,
 		UpstreamChecker:  upstreamChecker,
 		CheckTestVersion: checkTestVersion,
+		CheckerOptions:   checkerOptions,
 		CreatedAt:        now,
 		UpdatedAt:        now,
 	}
@@ -107,17 +108,17 @@ func (s *PackageService) AddPackage(name, upstreamUrl, versionExtractKey, upstre
 	if err := s.db.Preload("AurInfo").Preload("UpstreamInfo").First(&refreshedPkg, pkg.ID).Error; err != nil {
 		s.log.Errorf("刷新软件包信息失败: %v", err)
 		// 即使刷新失败，也尝试使用原始数据转换
-		return pkg.ToPackageDetail(), nil
+		return newPackageDetail(&pkg), nil
 	}
 
 	s.log.Infof("准备转换软件包为详情格式: %+v", refreshedPkg)
-	detail := refreshedPkg.ToPackageDetail()
+	detail := newPackageDetail(&refreshedPkg)
 	s.log.Infof("成功转换软件包为详情格式: %+v", detail)
 	return detail, nil
 }
 
 // UpdatePackage 更新软件包
-func (s *PackageService) UpdatePackage(id int, name, upstreamUrl, versionExtractKey, upstreamChecker string, checkTestVersion int) (database.PackageDetail, error) {
+func (s *PackageService) UpdatePackage(id int, name, upstreamUrl, versionExtractKey, upstreamChecker string, checkTestVersion int, checkerOptions string) (database.PackageDetail, error) {
 	// 查询软件包
 	var pkg database.PackageInfo
 	if err := s.db.First(&pkg, id).Error; err != nil {
@@ -131,6 +132,7 @@ func (s *PackageService) UpdatePackage(id int, name, upstreamUrl, versionExtract
 	pkg.VersionExtractKey = versionExtractKey
 	pkg.UpstreamChecker = upstreamChecker
 	pkg.CheckTestVersion = checkTestVersion
+	pkg.CheckerOptions = checkerOptions
 	pkg.UpdatedAt = time.Now()
 
 	// 保存更新
@@ -140,7 +142,7 @@ func (s *PackageService) UpdatePackage(id int, name, upstreamUrl, versionExtract
 	}
 
 	s.log.Infof("成功更新软件包(ID: %d): %s", id, name)
-	return pkg.ToPackageDetail(), nil
+	return newPackageDetail(&pkg), nil
 }
 
 // DeletePackage 删除软件包
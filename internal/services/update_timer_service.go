@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"database/sql"
 	"sync"
 	"time"
@@ -107,6 +108,7 @@ func (s *TimerService) runTimerTask() {
 	// 创建新的停止通道
 	oldStopChan := s.stopChan
 	s.stopChan = make(chan struct{})
+	stopChan := s.stopChan
 
 	// 设置下一次执行
 	s.timer = time.AfterFunc(s.interval, s.runTimerTask)
@@ -134,7 +136,17 @@ func (s *TimerService) runTimerTask() {
 
 	// 执行上游版本检查
 	s.log.Info("检查所有软件包的上游版本...")
-	_, err = s.upstreamService.CheckAllUpstreamVersions()
+	// 执行期间停止定时任务时，不再等待频率限制重置
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-stopChan:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	_, err = s.upstreamService.CheckAllUpstreamVersionsWithContext(ctx)
 	if err != nil {
 		s.log.Errorf("检查上游版本失败: %v", err)
 	} else {
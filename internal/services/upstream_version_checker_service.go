@@ -1,6 +1,7 @@
 package services
 
 import (
+	"aur-update-checker/internal/checkers/common"
 	checkers "aur-update-checker/internal/interfaces/checkers"
 	"aur-update-checker/internal/database"
 	"aur-update-checker/internal/logger"
@@ -8,17 +9,29 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
 
+const (
+	// maxRateLimitRequeueRounds 因频率限制被推迟的软件包最多重新排队的轮数
+	maxRateLimitRequeueRounds = 3
+	// maxRateLimitRequeueWait 等待频率限制重置的最长时间，超过时不再等待，被推迟的软件包在下次检查时处理
+	maxRateLimitRequeueWait = 10 * time.Minute
+)
+
 // UpstreamVersion 上游版本信息
 type UpstreamVersion struct {
 	Version string `json:"version"`
 	IsPrerelease bool `json:"isPrerelease"`
 	ReleaseDate string `json:"releaseDate,omitempty"`
 	DownloadURL string `json:"downloadUrl,omitempty"`
+	AssetName string `json:"assetName,omitempty"`
+	AssetSize int64 `json:"assetSize,omitempty"`
+	SourceURL string `json:"sourceUrl,omitempty"`
+	AssetSHA256 string `json:"assetSha256,omitempty"`
+	AssetSHA512 string `json:"assetSha512,omitempty"`
 }
 
 // UpstreamService 上游服务
@@ -60,18 +73,25 @@ func (s *UpstreamService) CheckUpstreamVersion(packageID int) ([]UpstreamVersion
 		return nil, err
 	}
 
-	// 获取上游版本信息
+	// 获取上游版本信息，软件包的检查器选项通过context传递给检查器
+	ctx := common.WithCheckOptions(context.Background(), common.ParseCheckOptions(pkg.CheckerOptions))
 	var versions []UpstreamVersion
 	var err error
 
 	if pkg.CheckTestVersion == 1 {
 		// 检查测试版本，使用带选项的方法
-		versions, err = s.getUpstreamVersionsWithOption(pkg.UpstreamUrl, pkg.VersionExtractKey, pkg.AurInfo.UpstreamVersionRef, pkg.UpstreamChecker, pkg.CheckTestVersion)
+		versions, err = s.getUpstreamVersionsWithOption(ctx, pkg.UpstreamUrl, pkg.VersionExtractKey, pkg.AurInfo.UpstreamVersionRef, pkg.UpstreamChecker, pkg.CheckTestVersion)
 	} else {
 		// 不检查测试版本，使用简单的方法
-		versions, err = s.getUpstreamVersions(pkg.UpstreamUrl, pkg.VersionExtractKey, pkg.AurInfo.UpstreamVersionRef, pkg.UpstreamChecker)
+		versions, err = s.getUpstreamVersions(ctx, pkg.UpstreamUrl, pkg.VersionExtractKey, pkg.AurInfo.UpstreamVersionRef, pkg.UpstreamChecker)
 	}
 	if err != nil {
+		// 频率限制不是软件包本身的问题，保留原有状态等待重新检查
+		if common.IsRateLimitError(err) {
+			s.log.Warnf("获取上游版本信息遇到频率限制(%s): %v", pkg.Name, err)
+			return nil, err
+		}
+
 		s.log.Errorf("获取上游版本信息失败(%s): %v", pkg.Name, err)
 
 		// 更新上游信息为失败状态
@@ -86,16 +106,27 @@ func (s *UpstreamService) CheckUpstreamVersion(packageID int) ([]UpstreamVersion
 		return nil, fmt.Errorf("未找到上游版本信息")
 	}
 
+	if err := s.saveUpstreamVersions(packageID, pkg.Name, pkg.CheckTestVersion, versions); err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+// saveUpstreamVersions 从检查到的版本中选出最新版本并保存到上游信息
+func (s *UpstreamService) saveUpstreamVersions(packageID int, packageName string, checkTestVersion int, versions []UpstreamVersion) error {
 	// 根据是否检查测试版本获取最新版本
+	latest := versions[0]
 	var latestVersion string
-	if pkg.CheckTestVersion == 1 {
+	if checkTestVersion == 1 {
 		// 检查测试版本，直接使用最新版本（可能是预发布版本）
 		latestVersion = versions[0].Version
-		s.log.Infof("软件包 %s 配置为检查测试版本，使用最新版本: %s", pkg.Name, latestVersion)
+		s.log.Infof("软件包 %s 配置为检查测试版本，使用最新版本: %s", packageName, latestVersion)
 	} else {
 		// 不检查测试版本，只获取稳定版本
 		for _, v := range versions {
 			if !v.IsPrerelease {
+				latest = v
 				latestVersion = v.Version
 				break
 			}
@@ -104,11 +135,11 @@ func (s *UpstreamService) CheckUpstreamVersion(packageID int) ([]UpstreamVersion
 		// 如果没有稳定版本，则使用第一个版本（可能是预发布版本）
 		if latestVersion == "" && len(versions) > 0 {
 			latestVersion = versions[0].Version
-			s.log.Warnf("软件包 %s 未找到稳定版本，使用预发布版本: %s", pkg.Name, latestVersion)
+			s.log.Warnf("软件包 %s 未找到稳定版本，使用预发布版本: %s", packageName, latestVersion)
 		} else if latestVersion == "" {
-			s.log.Warnf("软件包 %s 未找到任何版本", pkg.Name)
+			s.log.Warnf("软件包 %s 未找到任何版本", packageName)
 		} else {
-			s.log.Infof("软件包 %s 使用稳定版本: %s", pkg.Name, latestVersion)
+			s.log.Infof("软件包 %s 使用稳定版本: %s", packageName, latestVersion)
 		}
 	}
 
@@ -120,40 +151,61 @@ func (s *UpstreamService) CheckUpstreamVersion(packageID int) ([]UpstreamVersion
 			upstreamInfo = database.UpstreamInfo{
 				PackageID:           packageID,
 				UpstreamVersion:     latestVersion,
-				UpstreamUpdateDate:  utils.ParseReleaseDate(versions[0].ReleaseDate),
+				UpstreamUpdateDate:  utils.ParseReleaseDate(latest.ReleaseDate),
 				UpstreamUpdateState: 1, // 成功
 				CreatedAt:           utils.Now(),
 				UpdatedAt:           utils.Now(),
 			}
+			setUpstreamAsset(&upstreamInfo, latest)
 
 			if err := s.db.Create(&upstreamInfo).Error; err != nil {
 				s.log.Errorf("创建上游信息失败(ID: %d): %v", packageID, err)
-				return nil, err
+				return err
 			}
 		} else {
 			s.log.Errorf("查询上游信息失败(ID: %d): %v", packageID, err)
-			return nil, err
+			return err
 		}
 	} else {
 		// 更新现有的上游信息
 		upstreamInfo.UpstreamVersion = latestVersion
-		upstreamInfo.UpstreamUpdateDate = utils.ParseReleaseDate(versions[0].ReleaseDate)
+		upstreamInfo.UpstreamUpdateDate = utils.ParseReleaseDate(latest.ReleaseDate)
 		upstreamInfo.UpstreamUpdateState = 1 // 成功
 		upstreamInfo.UpdatedAt = utils.Now()
+		setUpstreamAsset(&upstreamInfo, latest)
 
 		if err := s.db.Save(&upstreamInfo).Error; err != nil {
 			s.log.Errorf("更新上游信息失败(ID: %d): %v", packageID, err)
-			return nil, err
+			return err
 		}
 	}
 
-	s.log.Infof("成功检查上游版本(%s): %s", pkg.Name, latestVersion)
+	s.log.Infof("成功检查上游版本(%s): %s", packageName, latestVersion)
 
-	return versions, nil
+	return nil
+}
+
+// setUpstreamAsset 将版本的发布时间、来源地址和附件信息写入上游信息，没有的字段会被清空
+func setUpstreamAsset(upstreamInfo *database.UpstreamInfo, version UpstreamVersion) {
+	upstreamInfo.UpstreamSourceUrl = version.SourceURL
+	upstreamInfo.UpstreamAssetName = version.AssetName
+	upstreamInfo.UpstreamAssetUrl = version.DownloadURL
+	upstreamInfo.UpstreamAssetSize = version.AssetSize
+	upstreamInfo.UpstreamAssetSha256 = version.AssetSHA256
+	upstreamInfo.UpstreamAssetSha512 = version.AssetSHA512
+	upstreamInfo.UpstreamPublishedAt = time.Time{}
+	if version.ReleaseDate != "" {
+		upstreamInfo.UpstreamPublishedAt = utils.ParseReleaseDate(version.ReleaseDate)
+	}
 }
 
 // CheckAllUpstreamVersions 检查所有软件包的上游版本
 func (s *UpstreamService) CheckAllUpstreamVersions() ([]database.PackageDetail, error) {
+	return s.CheckAllUpstreamVersionsWithContext(context.Background())
+}
+
+// CheckAllUpstreamVersionsWithContext 检查所有软件包的上游版本，ctx 取消时不再等待频率限制重置，返回已检查的结果
+func (s *UpstreamService) CheckAllUpstreamVersionsWithContext(ctx context.Context) ([]database.PackageDetail, error) {
 	// 获取所有软件包信息
 	packageService := NewPackageService(nil, s.log)
 	packages, err := packageService.GetAllPackages()
@@ -162,11 +214,26 @@ func (s *UpstreamService) CheckAllUpstreamVersions() ([]database.PackageDetail,
 		return nil, err
 	}
 
-	// 使用逐个检查方式
+	// 先使用支持批量检查的检查器检查，未成功的软件包再逐个检查
+	batchChecked := s.checkBatchUpstreamVersions(ctx, packages)
+
 	var results []database.PackageDetail
+	var deferred []int
+	var resetAt time.Time
 	for _, pkg := range packages {
-		_, err := s.CheckUpstreamVersion(pkg.ID)
+		var err error
+		if !batchChecked[pkg.ID] {
+			_, err = s.CheckUpstreamVersion(pkg.ID)
+		}
 		if err != nil {
+			// 遇到频率限制时推迟该软件包，等待限制重置后重新检查
+			if reset, ok := common.GetRateLimitReset(err); ok {
+				deferred = append(deferred, pkg.ID)
+				if reset.After(resetAt) {
+					resetAt = reset
+				}
+				continue
+			}
 			s.log.Errorf("检查软件包上游版本失败(ID: %d): %v", pkg.ID, err)
 			continue
 		}
@@ -180,62 +247,178 @@ func (s *UpstreamService) CheckAllUpstreamVersions() ([]database.PackageDetail,
 		results = append(results, detail)
 	}
 
+	// 重新检查因频率限制被推迟的软件包
+	for round := 1; len(deferred) > 0 && round <= maxRateLimitRequeueRounds; round++ {
+		if err := s.waitRateLimitReset(ctx, resetAt, len(deferred), round); err != nil {
+			s.log.Warnf("%v", err)
+			break
+		}
+
+		var next []int
+		for _, id := range deferred {
+			_, err := s.CheckUpstreamVersion(id)
+			if err != nil {
+				if reset, ok := common.GetRateLimitReset(err); ok {
+					next = append(next, id)
+					if reset.After(resetAt) {
+						resetAt = reset
+					}
+					continue
+				}
+				s.log.Errorf("检查软件包上游版本失败(ID: %d): %v", id, err)
+				continue
+			}
+
+			detail, err := packageService.GetPackageByID(id)
+			if err != nil {
+				s.log.Errorf("获取软件包详情失败(ID: %d): %v", id, err)
+				continue
+			}
+			results = append(results, detail)
+		}
+		deferred = next
+	}
+
+	if len(deferred) > 0 {
+		s.log.Warnf("%d个软件包仍受频率限制，保留原有状态，下次检查时重新检查", len(deferred))
+	}
+
 	s.log.Infof("成功检查所有软件包的上游版本，共%d个", len(results))
 	return results, nil
 }
 
-// getUpstreamVersions 获取上游版本信息
-func (s *UpstreamService) getUpstreamVersions(upstreamUrl, versionExtractKey, versionRef string, checkerType string) ([]UpstreamVersion, error) {
-	// 使用检查器工厂获取上游版本
-	// 使用 CheckWithVersionRef 方法传递版本引用
+// waitRateLimitReset 等待频率限制重置，重置时间超过 maxRateLimitRequeueWait 或 ctx 被取消时返回错误
+func (s *UpstreamService) waitRateLimitReset(ctx context.Context, resetAt time.Time, deferred, round int) error {
+	wait := time.Until(resetAt)
+	if wait <= 0 {
+		return nil
+	}
+	if wait > maxRateLimitRequeueWait {
+		return fmt.Errorf("频率限制在 %v 后才重置，超过最长等待时间 %v，不再重新检查", wait.Round(time.Second), maxRateLimitRequeueWait)
+	}
 
-	version, err := s.factory.CheckWithVersionRef(context.Background(), checkerType, upstreamUrl, versionExtractKey, versionRef, 0)
-	if err != nil {
-		return nil, fmt.Errorf("使用检查器 '%s' 提取版本失败: %v", checkerType, err)
+	s.log.Infof("%d个软件包因频率限制被推迟，等待 %v 后进行第%d轮重新检查", deferred, wait.Round(time.Second), round)
+	timer := time.NewTimer(wait + time.Second)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("等待频率限制重置时检查被取消: %v", ctx.Err())
+	case <-timer.C:
+		return nil
 	}
+}
 
-	// 创建UpstreamVersion对象
-	var upstreamVersion UpstreamVersion
-	upstreamVersion.Version = version
-	upstreamVersion.IsPrerelease = !utils.IsVersionStable(version)
+// checkBatchUpstreamVersions 按检查器分组，使用实现了BatchUpstreamChecker接口的检查器批量检查软件包
+// 返回已成功检查并保存结果的软件包ID，批量检查失败的软件包不做处理，由调用方逐个重新检查
+// ctx 取消时正在进行的批量请求随之取消，不再检查剩余的分组
+func (s *UpstreamService) checkBatchUpstreamVersions(ctx context.Context, packages []database.PackageDetail) map[int]bool {
+	checked := make(map[int]bool)
 
-	// 构建下载URL（这里简化处理，实际可能需要根据不同的检查器类型进行特殊处理）
-	if strings.Contains(upstreamUrl, "github.com") {
-		upstreamVersion.DownloadURL = upstreamUrl + "/releases/download/v" + version + "/release.tar.gz"
-	} else if strings.Contains(upstreamUrl, "gitlab.com") {
-		upstreamVersion.DownloadURL = upstreamUrl + "/-/archive/v" + version + "/project-v" + version + ".tar.gz"
-	} else {
-		upstreamVersion.DownloadURL = upstreamUrl + "/downloads/" + version + ".tar.gz"
+	groups := make(map[string][]database.PackageDetail)
+	for _, pkg := range packages {
+		groups[pkg.UpstreamChecker] = append(groups[pkg.UpstreamChecker], pkg)
+	}
+
+	for checkerName, group := range groups {
+		if ctx.Err() != nil {
+			break
+		}
+		checker, err := s.factory.GetChecker(checkerName)
+		if err != nil {
+			continue
+		}
+		batchChecker, ok := checker.(common.BatchUpstreamChecker)
+		if !ok {
+			continue
+		}
+
+		requests := make([]common.BatchCheckRequest, len(group))
+		for i, pkg := range group {
+			requests[i] = common.BatchCheckRequest{
+				URL:               pkg.UpstreamUrl,
+				VersionExtractKey: pkg.VersionExtractKey,
+				VersionRef:        pkg.UpstreamVersionRef,
+				CheckTestVersion:  pkg.CheckTestVersion,
+				Options:           common.ParseCheckOptions(pkg.CheckerOptions),
+			}
+		}
+
+		s.log.Infof("使用检查器 '%s' 批量检查%d个软件包", checkerName, len(group))
+		batchResults := batchChecker.CheckBatch(ctx, requests)
+
+		failed := 0
+		for i, result := range batchResults {
+			pkg := group[i]
+			if result.Err != nil {
+				failed++
+				s.log.Debugf("批量检查软件包失败(%s)，将改用逐个检查: %v", pkg.Name, result.Err)
+				continue
+			}
+
+			upstreamVersion := s.newUpstreamVersion(pkg.Name, result.Result, requests[i].Options.Get(common.OptionAsset, ""))
+			if err := s.saveUpstreamVersions(pkg.ID, pkg.Name, pkg.CheckTestVersion, []UpstreamVersion{upstreamVersion}); err != nil {
+				failed++
+				continue
+			}
+			checked[pkg.ID] = true
+		}
+
+		if failed > 0 {
+			s.log.Warnf("检查器 '%s' 批量检查中有%d个软件包失败，将改用逐个检查", checkerName, failed)
+		}
 	}
 
-	return []UpstreamVersion{upstreamVersion}, nil
+	return checked
 }
 
-// getUpstreamVersionsWithOption 根据选项获取上游版本信息
-func (s *UpstreamService) getUpstreamVersionsWithOption(upstreamUrl, versionExtractKey, versionRef string, checkerType string, checkTestVersion int) ([]UpstreamVersion, error) {
-	// 使用检查器工厂获取上游版本
-	// 使用 CheckWithVersionRef 方法传递版本引用
+// getUpstreamVersions 获取上游版本信息
+func (s *UpstreamService) getUpstreamVersions(ctx context.Context, upstreamUrl, versionExtractKey, versionRef string, checkerType string) ([]UpstreamVersion, error) {
+	return s.getUpstreamVersionsWithOption(ctx, upstreamUrl, versionExtractKey, versionRef, checkerType, 0)
+}
 
-	version, err := s.factory.CheckWithVersionRef(context.Background(), checkerType, upstreamUrl, versionExtractKey, versionRef, checkTestVersion)
+// getUpstreamVersionsWithOption 根据选项获取上游版本信息
+func (s *UpstreamService) getUpstreamVersionsWithOption(ctx context.Context, upstreamUrl, versionExtractKey, versionRef string, checkerType string, checkTestVersion int) ([]UpstreamVersion, error) {
+	// 使用检查器工厂获取上游检查结果，检查器能获取时会同时返回发布时间和附件列表
+	result, err := s.factory.CheckWithResult(ctx, checkerType, upstreamUrl, versionExtractKey, versionRef, checkTestVersion)
 	if err != nil {
-		return nil, fmt.Errorf("使用检查器 '%s' 提取版本失败: %v", checkerType, err)
+		return nil, fmt.Errorf("使用检查器 '%s' 提取版本失败: %w", checkerType, err)
 	}
 
-	// 创建UpstreamVersion对象
-	var upstreamVersion UpstreamVersion
-	upstreamVersion.Version = version
-	upstreamVersion.IsPrerelease = !utils.IsVersionStable(version)
+	assetPattern := common.CheckOptionsFromContext(ctx).Get(common.OptionAsset, "")
+	return []UpstreamVersion{s.newUpstreamVersion(upstreamUrl, result, assetPattern)}, nil
+}
+
+// newUpstreamVersion 根据检查结果创建UpstreamVersion对象
+// 配置了附件匹配模式时，使用匹配到的附件作为下载地址，否则使用上游指定的主文件
+func (s *UpstreamService) newUpstreamVersion(name string, release *common.UpstreamCheckResult, assetPattern string) UpstreamVersion {
+	upstreamVersion := UpstreamVersion{
+		Version:      release.Version,
+		IsPrerelease: release.IsPrerelease,
+		SourceURL:    release.SourceURL,
+	}
+	if !release.ReleaseDate.IsZero() {
+		upstreamVersion.ReleaseDate = release.ReleaseDate.Format(time.RFC3339)
+	}
 
-	// 构建下载URL（这里简化处理，实际可能需要根据不同的检查器类型进行特殊处理）
-	if strings.Contains(upstreamUrl, "github.com") {
-		upstreamVersion.DownloadURL = upstreamUrl + "/releases/download/v" + version + "/release.tar.gz"
-	} else if strings.Contains(upstreamUrl, "gitlab.com") {
-		upstreamVersion.DownloadURL = upstreamUrl + "/-/archive/v" + version + "/project-v" + version + ".tar.gz"
+	var asset *common.ReleaseAsset
+	if assetPattern == "" {
+		asset = common.DefaultReleaseAsset(release.Assets)
 	} else {
-		upstreamVersion.DownloadURL = upstreamUrl + "/downloads/" + version + ".tar.gz"
+		asset = common.MatchReleaseAsset(release.Assets, assetPattern)
+		if asset == nil {
+			s.log.Warnf("%s 的发布 %s 中没有匹配 '%s' 的附件(共%d个附件)", name, release.Version, assetPattern, len(release.Assets))
+		}
+	}
+	if asset == nil {
+		return upstreamVersion
 	}
 
-	return []UpstreamVersion{upstreamVersion}, nil
+	upstreamVersion.AssetName = asset.Name
+	upstreamVersion.DownloadURL = asset.DownloadURL
+	upstreamVersion.AssetSize = asset.Size
+	upstreamVersion.AssetSHA256 = asset.SHA256
+	upstreamVersion.AssetSHA512 = asset.SHA512
+	return upstreamVersion
 }
 
 // updateUpstreamInfoFailed 更新上游信息为失败状态
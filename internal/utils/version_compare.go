@@ -169,6 +169,7 @@ func ParseReleaseDate(dateStr string) time.Time {
 	formats := []string{
 		"2006-01-02",
 		"2006-01-02T15:04:05Z",
+		time.RFC3339,
 		"2006-01-02 15:04:05",
 		"01/02/2006",
 		"Jan 2, 2006",